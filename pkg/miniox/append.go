@@ -0,0 +1,190 @@
+package miniox
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+)
+
+// minComposePartSize is the smallest size S3 accepts for every compose source except the last one
+const minComposePartSize = 5 * 1024 * 1024
+
+// AppendOptions configures AppendObjectWithOpts
+type AppendOptions struct {
+	// ReadConcatFallback rewrites the whole object (download, concatenate, upload) when the existing
+	// object is smaller than 5 MiB and cannot be used as a compose source
+	ReadConcatFallback bool
+
+	// Native uses the server-side append API (x-amz-write-offset-bytes), supported by S3 Express One Zone
+	// directory buckets and MinIO AIStor. Requires Config.EnableChecksums, since appends carry trailing checksums
+	Native bool
+}
+
+// ErrObjectTooSmallToCompose is returned when an object cannot be appended to with ComposeObject
+// because it is smaller than the 5 MiB minimum part size
+type ErrObjectTooSmallToCompose struct {
+	ObjectPath string // Relative object path
+	Size       int64  // Current object size in bytes
+}
+
+// Error implements the error interface
+func (e *ErrObjectTooSmallToCompose) Error() string {
+	return fmt.Sprintf("cannot append to %s: object is %d bytes, compose requires at least %d", e.ObjectPath, e.Size, minComposePartSize)
+}
+
+// AppendObject appends data to an object, creating it if it does not exist
+// See AppendObjectWithOpts for the limitations of appending via ComposeObject
+func (c *Client) AppendObject(ctx context.Context, objectPath string, reader io.Reader, objectSize int64) (minio.UploadInfo, error) {
+	return c.AppendObjectWithOpts(ctx, objectPath, reader, objectSize, AppendOptions{})
+}
+
+// AppendObjectWithOpts appends data to an object, creating it if it does not exist
+// The data is uploaded to a temporary object that is composed after the existing object and then removed.
+// S3 requires every compose source except the last to be at least 5 MiB, so appending to a smaller object
+// returns *ErrObjectTooSmallToCompose unless opts.ReadConcatFallback is set. The existing object is pinned by
+// its ETag, so a concurrent write makes the append fail with *ErrPreconditionFailed instead of losing data.
+// With opts.Native the data is appended in place at the current object length instead; the server rejects the
+// append when the length changed in the meantime
+func (c *Client) AppendObjectWithOpts(ctx context.Context, objectPath string, reader io.Reader, objectSize int64, opts AppendOptions) (uploadInfo minio.UploadInfo, err error) {
+	ctx, span := c.startOperation(ctx, "AppendObject",
+		slog.String("object", objectPath),
+		slog.Int64("size", objectSize))
+	defer func() { span.End(err) }()
+
+	if err := c.validatePath(ctx, objectPath); err != nil {
+		return minio.UploadInfo{}, err
+	}
+
+	fullPath := c.buildPath(ctx, objectPath)
+
+	if opts.Native {
+		return c.appendNative(ctx, objectPath, reader, objectSize)
+	}
+
+	// Bypass the stat cache, the append must be based on the current object
+	existing, err := withRetry(ctx, c, "AppendObject", func() (minio.ObjectInfo, error) {
+		return c.minio.StatObject(ctx, c.bucketName, fullPath, minio.StatObjectOptions{ServerSideEncryption: c.readSSE(nil)})
+	})
+	if err != nil {
+		if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+			return c.createObject(ctx, objectPath, reader, objectSize)
+		}
+		return minio.UploadInfo{}, err
+	}
+
+	c.logDebug(ctx, "[MinIO] Appending to object",
+		slog.String("bucket", c.bucketName),
+		slog.String("object", fullPath),
+		slog.Int64("existingSize", existing.Size),
+		slog.Int64("size", objectSize))
+
+	if existing.Size < minComposePartSize {
+		if !opts.ReadConcatFallback {
+			return minio.UploadInfo{}, &ErrObjectTooSmallToCompose{ObjectPath: objectPath, Size: existing.Size}
+		}
+		return c.appendByRewrite(ctx, objectPath, existing, reader, objectSize)
+	}
+
+	chunkPath := objectPath + ".append-" + strconv.FormatInt(time.Now().UnixNano(), 36)
+	if _, err := c.PutObject(ctx, chunkPath, reader, objectSize, minio.PutObjectOptions{}); err != nil {
+		return minio.UploadInfo{}, fmt.Errorf("failed to upload append chunk: %w", err)
+	}
+	defer func() {
+		// Clean up even if the caller's context was cancelled
+		if removeErr := c.RemoveObject(context.WithoutCancel(ctx), chunkPath, minio.RemoveObjectOptions{}); removeErr != nil {
+			c.logDebug(ctx, "[MinIO] Failed to remove append chunk",
+				slog.String("bucket", c.bucketName),
+				slog.String("object", chunkPath),
+				slog.String("error", removeErr.Error()))
+		}
+	}()
+
+	sources := []minio.CopySrcOptions{
+		{Object: objectPath, MatchETag: existing.ETag},
+		{Object: chunkPath},
+	}
+	uploadInfo, err = c.ComposeObject(ctx, objectPath, sources, minio.CopyDestOptions{})
+	if err != nil {
+		if isPreconditionFailed(err) {
+			return minio.UploadInfo{}, &ErrPreconditionFailed{
+				ObjectPath: objectPath,
+				Condition:  fmt.Sprintf("If-Match: %q", existing.ETag),
+				Err:        err,
+			}
+		}
+		return minio.UploadInfo{}, err
+	}
+
+	return uploadInfo, nil
+}
+
+// appendNative appends with the server-side append API, creating the object if it does not exist
+func (c *Client) appendNative(ctx context.Context, objectPath string, reader io.Reader, objectSize int64) (minio.UploadInfo, error) {
+	if !c.checksums {
+		return minio.UploadInfo{}, fmt.Errorf("native append requires Config.EnableChecksums")
+	}
+
+	fullPath := c.buildPath(ctx, objectPath)
+	defer c.invalidateFullPath(fullPath)
+
+	c.logDebug(ctx, "[MinIO] Appending to object natively",
+		slog.String("bucket", c.bucketName),
+		slog.String("object", fullPath),
+		slog.Int64("size", objectSize))
+
+	// The append is not retried, a retry after a partially applied request would duplicate data
+	uploadInfo, err := c.minio.AppendObject(ctx, c.bucketName, fullPath, reader, objectSize, minio.AppendObjectOptions{})
+	if err != nil {
+		if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+			return c.createObject(ctx, objectPath, reader, objectSize)
+		}
+		return minio.UploadInfo{}, err
+	}
+
+	uploadInfo.Key = c.stripBasePath(ctx, uploadInfo.Key)
+	return uploadInfo, nil
+}
+
+// createObject creates a new object, reporting an object created concurrently as a precondition failure
+func (c *Client) createObject(ctx context.Context, objectPath string, reader io.Reader, objectSize int64) (minio.UploadInfo, error) {
+	uploadInfo, created, err := c.PutObjectIfNotExists(ctx, objectPath, reader, objectSize, minio.PutObjectOptions{})
+	if err != nil {
+		return minio.UploadInfo{}, err
+	}
+	if !created {
+		return minio.UploadInfo{}, &ErrPreconditionFailed{ObjectPath: objectPath, Condition: "If-None-Match: *"}
+	}
+	return uploadInfo, nil
+}
+
+// appendByRewrite appends by downloading the existing object and uploading it again followed by the new data
+func (c *Client) appendByRewrite(ctx context.Context, objectPath string, existing minio.ObjectInfo, reader io.Reader, objectSize int64) (minio.UploadInfo, error) {
+	getOpts := minio.GetObjectOptions{}
+	if err := getOpts.SetMatchETag(existing.ETag); err != nil {
+		return minio.UploadInfo{}, err
+	}
+
+	object, err := c.GetObject(ctx, objectPath, getOpts)
+	if err != nil {
+		return minio.UploadInfo{}, err
+	}
+	defer object.Close()
+
+	totalSize := int64(-1)
+	if objectSize >= 0 {
+		totalSize = existing.Size + objectSize
+	}
+
+	opts := minio.PutObjectOptions{
+		ContentType:  existing.ContentType,
+		UserMetadata: existing.UserMetadata,
+	}
+
+	// The rewrite is not seekable, so PutObjectIfMatch uploads it without retries
+	return c.PutObjectIfMatch(ctx, objectPath, existing.ETag, io.MultiReader(object, reader), totalSize, opts)
+}
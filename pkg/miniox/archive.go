@@ -0,0 +1,431 @@
+package miniox
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/minio/minio-go/v7"
+)
+
+// ArchiveFormat selects the archive container used by ArchivePrefix
+type ArchiveFormat int
+
+const (
+	ArchiveTar ArchiveFormat = iota // Uncompressed POSIX tar stream
+	ArchiveZip                      // Zip stream with deflate compression
+)
+
+// String returns the format name
+func (f ArchiveFormat) String() string {
+	switch f {
+	case ArchiveTar:
+		return "tar"
+	case ArchiveZip:
+		return "zip"
+	default:
+		return fmt.Sprintf("ArchiveFormat(%d)", int(f))
+	}
+}
+
+// archiveWriter writes archive entries one object at a time
+type archiveWriter interface {
+	writeEntry(name string, info minio.ObjectInfo, r io.Reader) error
+	Close() error
+}
+
+// ArchivePrefix streams all objects under a prefix into a tar or zip archive written to w
+// Entry names are object paths relative to the base directory prefix, and folder markers are skipped.
+// Objects are fetched one at a time, so memory use does not depend on the prefix size. On error or context
+// cancellation the archive is left unterminated, so a truncated stream is never mistaken for a complete one
+func (c *Client) ArchivePrefix(ctx context.Context, prefix string, w io.Writer, format ArchiveFormat) (err error) {
+	ctx, span := c.startSpan(ctx, "ArchivePrefix",
+		slog.String("prefix", prefix),
+		slog.String("format", format.String()))
+	defer func() { span.End(err) }()
+
+	var archive archiveWriter
+	switch format {
+	case ArchiveTar:
+		archive = &tarArchiveWriter{tw: tar.NewWriter(w)}
+	case ArchiveZip:
+		archive = &zipArchiveWriter{zw: zip.NewWriter(w)}
+	default:
+		return fmt.Errorf("unsupported archive format: %s", format)
+	}
+
+	c.logDebug(ctx, "[MinIO] Archiving prefix",
+		slog.String("bucket", c.bucketName),
+		slog.String("prefix", prefix),
+		slog.String("format", format.String()))
+
+	// Stop the listing when returning early
+	listCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	for objectInfo := range c.ListObjects(listCtx, prefix, true) {
+		if objectInfo.Err != nil {
+			return objectInfo.Err
+		}
+		if isFolderMarker(objectInfo.Key) {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if err := c.archiveObject(ctx, archive, objectInfo.Key); err != nil {
+			return err
+		}
+	}
+
+	if err := archive.Close(); err != nil {
+		return fmt.Errorf("failed to finish %s archive: %w", format, err)
+	}
+	return nil
+}
+
+// archiveObject downloads a single object into the archive
+// The entry is described by the object actually fetched, so changes after listing cannot corrupt the stream
+func (c *Client) archiveObject(ctx context.Context, archive archiveWriter, objectPath string) error {
+	object, info, err := c.OpenObject(ctx, objectPath, minio.GetObjectOptions{})
+	if err != nil {
+		if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+			// Removed since it was listed
+			return nil
+		}
+		return fmt.Errorf("failed to open %s: %w", objectPath, err)
+	}
+	defer object.Close()
+
+	if err := archive.writeEntry(objectPath, info, object); err != nil {
+		return fmt.Errorf("failed to archive %s: %w", objectPath, err)
+	}
+	return nil
+}
+
+// tarArchiveWriter writes objects as regular tar entries
+type tarArchiveWriter struct {
+	tw *tar.Writer
+}
+
+func (a *tarArchiveWriter) writeEntry(name string, info minio.ObjectInfo, r io.Reader) error {
+	header := &tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     name,
+		Size:     info.Size,
+		Mode:     0o644,
+		ModTime:  info.LastModified,
+		Format:   tar.FormatPAX,
+	}
+	if err := a.tw.WriteHeader(header); err != nil {
+		return err
+	}
+	_, err := io.Copy(a.tw, r)
+	return err
+}
+
+func (a *tarArchiveWriter) Close() error {
+	return a.tw.Close()
+}
+
+// zipArchiveWriter writes objects as deflated zip entries
+type zipArchiveWriter struct {
+	zw *zip.Writer
+}
+
+func (a *zipArchiveWriter) writeEntry(name string, info minio.ObjectInfo, r io.Reader) error {
+	entry, err := a.zw.CreateHeader(&zip.FileHeader{
+		Name:     name,
+		Method:   zip.Deflate,
+		Modified: info.LastModified,
+	})
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(entry, r)
+	return err
+}
+
+func (a *zipArchiveWriter) Close() error {
+	return a.zw.Close()
+}
+
+// importBufferSize is the largest tar entry buffered in memory so it can be uploaded concurrently;
+// larger entries are streamed directly from the archive
+const importBufferSize = defaultPartSize
+
+// ImportOptions configures ImportArchiveWithOpts
+type ImportOptions struct {
+	Concurrency         int  // Number of parallel uploads (default 1)
+	CreateFolderMarkers bool // Create folder markers for directory entries instead of skipping them
+}
+
+// importJob uploads a single archive entry
+type importJob struct {
+	objectPath string
+	size       int64
+	open       func() (io.ReadCloser, error)
+}
+
+// ImportArchive extracts a tar or zip stream into a destination prefix, skipping directory entries
+// This is the inverse of ArchivePrefix; see ImportArchiveWithOpts for details
+func (c *Client) ImportArchive(ctx context.Context, destPrefix string, r io.Reader, format ArchiveFormat, concurrency int) error {
+	return c.ImportArchiveWithOpts(ctx, destPrefix, r, format, ImportOptions{Concurrency: concurrency})
+}
+
+// ImportArchiveWithOpts extracts a tar or zip stream into a destination prefix
+// Every entry name is checked with ValidatePath, so archives with traversing or absolute names (zip-slip) are rejected.
+// Only regular files and directories are imported; links and special files are skipped. Zip archives need random
+// access, so a reader that is not an io.ReaderAt and io.Seeker is spooled to a temporary file first.
+// The import stops at the first error; objects uploaded before it are kept
+func (c *Client) ImportArchiveWithOpts(ctx context.Context, destPrefix string, r io.Reader, format ArchiveFormat, opts ImportOptions) (err error) {
+	ctx, span := c.startSpan(ctx, "ImportArchive",
+		slog.String("prefix", destPrefix),
+		slog.String("format", format.String()))
+	defer func() { span.End(err) }()
+
+	if err := c.validatePath(ctx, destPrefix); err != nil {
+		return err
+	}
+
+	c.logDebug(ctx, "[MinIO] Importing archive",
+		slog.String("bucket", c.bucketName),
+		slog.String("prefix", c.buildFolderPath(ctx, destPrefix)),
+		slog.String("format", format.String()))
+
+	concurrency := max(opts.Concurrency, 1)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		firstErr error
+	)
+	fail := func(err error) {
+		mu.Lock()
+		if firstErr == nil {
+			firstErr = err
+		}
+		mu.Unlock()
+		cancel()
+	}
+
+	jobs := make(chan importJob)
+	for range concurrency {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range jobs {
+				// Entries still queued after an error are left untouched
+				if ctx.Err() != nil {
+					continue
+				}
+				if err := c.importEntry(ctx, job); err != nil {
+					fail(err)
+				}
+			}
+		}()
+	}
+
+	enqueue := func(job importJob) error {
+		select {
+		case jobs <- job:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	var readErr error
+	cleanup := func() {}
+	switch format {
+	case ArchiveTar:
+		readErr = c.readTarEntries(ctx, destPrefix, r, opts, enqueue)
+	case ArchiveZip:
+		cleanup, readErr = c.readZipEntries(ctx, destPrefix, r, opts, enqueue)
+	default:
+		readErr = fmt.Errorf("unsupported archive format: %s", format)
+	}
+	close(jobs)
+	wg.Wait()
+
+	// Workers open zip entries from the spooled archive, so it is only removed once they are done
+	cleanup()
+
+	// A worker error cancels the reader, so it takes precedence over the resulting context error
+	if firstErr != nil {
+		return firstErr
+	}
+	return readErr
+}
+
+// importEntry uploads a single archive entry
+func (c *Client) importEntry(ctx context.Context, job importJob) error {
+	reader, err := job.open()
+	if err != nil {
+		return fmt.Errorf("failed to read archive entry %s: %w", job.objectPath, err)
+	}
+	defer reader.Close()
+
+	if _, err := c.PutObject(ctx, job.objectPath, reader, job.size, minio.PutObjectOptions{}); err != nil {
+		return fmt.Errorf("failed to import %s: %w", job.objectPath, err)
+	}
+	return nil
+}
+
+// importObjectPath validates an archive entry name and resolves it against the destination prefix
+func (c *Client) importObjectPath(destPrefix string, name string) (string, error) {
+	cleanName := strings.TrimPrefix(toSlash(name), "./")
+	if err := c.ValidatePath(cleanName); err != nil {
+		return "", fmt.Errorf("invalid archive entry %s: %w", name, err)
+	}
+
+	cleanPrefix := strings.Trim(toSlash(destPrefix), "/")
+	if cleanPrefix == "" {
+		return cleanName, nil
+	}
+	return cleanPrefix + "/" + cleanName, nil
+}
+
+// readTarEntries reads a tar stream and enqueues its entries
+// Small entries are buffered so they can be uploaded concurrently; large entries are uploaded from the stream
+func (c *Client) readTarEntries(ctx context.Context, destPrefix string, r io.Reader, opts ImportOptions, enqueue func(importJob) error) error {
+	tr := tar.NewReader(r)
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read tar archive: %w", err)
+		}
+
+		objectPath, err := c.importObjectPath(destPrefix, header.Name)
+		if err != nil {
+			return err
+		}
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if opts.CreateFolderMarkers {
+				if err := c.CreateFolder(ctx, objectPath); err != nil {
+					return err
+				}
+			}
+			continue
+		case tar.TypeReg:
+		default:
+			continue
+		}
+
+		if header.Size > importBufferSize {
+			job := importJob{objectPath: objectPath, size: header.Size, open: func() (io.ReadCloser, error) {
+				return io.NopCloser(tr), nil
+			}}
+			if err := c.importEntry(ctx, job); err != nil {
+				return err
+			}
+			continue
+		}
+
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return fmt.Errorf("failed to read tar entry %s: %w", header.Name, err)
+		}
+		job := importJob{objectPath: objectPath, size: int64(len(data)), open: func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(data)), nil
+		}}
+		if err := enqueue(job); err != nil {
+			return err
+		}
+	}
+}
+
+// readZipEntries reads a zip archive and enqueues its entries
+// The returned cleanup releases the spooled archive and must only be called once every enqueued job has finished
+func (c *Client) readZipEntries(ctx context.Context, destPrefix string, r io.Reader, opts ImportOptions, enqueue func(importJob) error) (cleanup func(), err error) {
+	readerAt, size, cleanup, err := zipReaderAt(r)
+	if err != nil {
+		return func() {}, err
+	}
+
+	zr, err := zip.NewReader(readerAt, size)
+	if err != nil {
+		return cleanup, fmt.Errorf("failed to read zip archive: %w", err)
+	}
+
+	for _, file := range zr.File {
+		if err := ctx.Err(); err != nil {
+			return cleanup, err
+		}
+
+		objectPath, err := c.importObjectPath(destPrefix, file.Name)
+		if err != nil {
+			return cleanup, err
+		}
+
+		mode := file.Mode()
+		switch {
+		case mode.IsDir():
+			if opts.CreateFolderMarkers {
+				if err := c.CreateFolder(ctx, objectPath); err != nil {
+					return cleanup, err
+				}
+			}
+			continue
+		case !mode.IsRegular():
+			continue
+		}
+
+		job := importJob{objectPath: objectPath, size: int64(file.UncompressedSize64), open: file.Open}
+		if err := enqueue(job); err != nil {
+			return cleanup, err
+		}
+	}
+
+	return cleanup, nil
+}
+
+// zipReaderAt returns random access to a zip stream, spooling it to a temporary file when necessary
+func zipReaderAt(r io.Reader) (io.ReaderAt, int64, func(), error) {
+	if readerAt, ok := r.(io.ReaderAt); ok {
+		if seeker, ok := r.(io.Seeker); ok {
+			size, err := seeker.Seek(0, io.SeekEnd)
+			if err != nil {
+				return nil, 0, nil, fmt.Errorf("failed to determine zip archive size: %w", err)
+			}
+			return readerAt, size, func() {}, nil
+		}
+	}
+
+	file, err := os.CreateTemp("", "miniox-import-*.zip")
+	if err != nil {
+		return nil, 0, nil, fmt.Errorf("failed to create temporary file for zip archive: %w", err)
+	}
+	cleanup := func() {
+		file.Close()
+		os.Remove(file.Name())
+	}
+
+	size, err := io.Copy(file, r)
+	if err != nil {
+		cleanup()
+		return nil, 0, nil, fmt.Errorf("failed to spool zip archive: %w", err)
+	}
+
+	return file, size, cleanup, nil
+}
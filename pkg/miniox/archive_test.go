@@ -0,0 +1,146 @@
+package miniox
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"testing"
+)
+
+// testArchiveEntries returns count small entries named f0.txt, f1.txt, ...
+func testArchiveEntries(count int) map[string]string {
+	entries := make(map[string]string, count)
+	for i := range count {
+		entries[fmt.Sprintf("f%d.txt", i)] = fmt.Sprintf("content of entry %d", i)
+	}
+	return entries
+}
+
+func buildZip(t *testing.T, entries map[string]string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range entries {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := io.WriteString(w, content); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func buildTar(t *testing.T, entries map[string]string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	if err := tw.WriteHeader(&tar.Header{Name: "sub/", Typeflag: tar.TypeDir, Mode: 0o755}); err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range entries {
+		if err := tw.WriteHeader(&tar.Header{Name: name, Typeflag: tar.TypeReg, Mode: 0o644, Size: int64(len(content))}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := io.WriteString(tw, content); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func assertImported(t *testing.T, fake *fakeS3, prefix string, entries map[string]string) {
+	t.Helper()
+
+	for name, content := range entries {
+		object := fake.object(prefix + name)
+		if object == nil {
+			t.Errorf("entry %s was not imported", name)
+			continue
+		}
+		if string(object.data) != content {
+			t.Errorf("entry %s = %q, want %q", name, object.data, content)
+		}
+	}
+}
+
+func TestImportArchiveZip(t *testing.T) {
+	entries := testArchiveEntries(5)
+	archive := buildZip(t, entries)
+
+	tests := []struct {
+		name        string
+		reader      func() io.Reader
+		concurrency int
+	}{
+		// A plain reader (e.g. an HTTP request body) is spooled to a temporary file
+		{"spooled", func() io.Reader { return io.MultiReader(bytes.NewReader(archive)) }, 1},
+		{"spooled concurrent", func() io.Reader { return io.MultiReader(bytes.NewReader(archive)) }, 4},
+		{"random access", func() io.Reader { return bytes.NewReader(archive) }, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, fake := newFakeS3Client(t, "app-data")
+
+			if err := c.ImportArchive(context.Background(), "dst", tt.reader(), ArchiveZip, tt.concurrency); err != nil {
+				t.Fatalf("ImportArchive: %v", err)
+			}
+			assertImported(t, fake, "app-data/dst/", entries)
+		})
+	}
+}
+
+func TestImportArchiveTarConcurrent(t *testing.T) {
+	ctx := context.Background()
+	c, fake := newFakeS3Client(t, "app-data")
+
+	entries := testArchiveEntries(20)
+	err := c.ImportArchiveWithOpts(ctx, "dst", bytes.NewReader(buildTar(t, entries)), ArchiveTar, ImportOptions{
+		Concurrency:         4,
+		CreateFolderMarkers: true,
+	})
+	if err != nil {
+		t.Fatalf("ImportArchiveWithOpts: %v", err)
+	}
+
+	assertImported(t, fake, "app-data/dst/", entries)
+	if fake.object("app-data/dst/sub/.empty") == nil {
+		t.Error("folder marker for the directory entry was not created")
+	}
+}
+
+func TestImportArchiveRejectsTraversal(t *testing.T) {
+	ctx := context.Background()
+
+	for _, format := range []ArchiveFormat{ArchiveTar, ArchiveZip} {
+		t.Run(format.String(), func(t *testing.T) {
+			c, fake := newFakeS3Client(t, "app-data")
+
+			entries := map[string]string{"../escape.txt": "zip-slip"}
+			archive := buildTar(t, entries)
+			if format == ArchiveZip {
+				archive = buildZip(t, entries)
+			}
+
+			if err := c.ImportArchive(ctx, "dst", bytes.NewReader(archive), format, 1); err == nil {
+				t.Error("ImportArchive accepted a traversing entry name")
+			}
+			if fake.object("app-data/escape.txt") != nil || fake.object("app-data/dst/../escape.txt") != nil {
+				t.Error("traversing entry was imported")
+			}
+		})
+	}
+}
@@ -2,47 +2,277 @@ package miniox
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
 
-	"github.com/aeternitas-infinita/rmlog"
 	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/notification"
+	"github.com/minio/minio-go/v7/pkg/tags"
 )
 
 // BucketExists checks if the configured bucket exists
-func (c *Client) BucketExists(ctx context.Context) (bool, error) {
-	rmlog.DebugCtxMin(ctx, "[MinIO] Checking bucket existence",
+func (c *Client) BucketExists(ctx context.Context) (exists bool, err error) {
+	ctx, span := c.startOperation(ctx, "BucketExists")
+	defer func() { span.End(err) }()
+
+	c.logDebug(ctx, "[MinIO] Checking bucket existence",
 		slog.String("bucket", c.bucketName))
 
 	return c.minio.BucketExists(ctx, c.bucketName)
 }
 
 // ListBuckets lists all buckets (no prefix applied here as it's bucket-level operation)
-func (c *Client) ListBuckets(ctx context.Context) ([]minio.BucketInfo, error) {
-	rmlog.DebugCtxMin(ctx, "[MinIO] Listing all buckets")
+func (c *Client) ListBuckets(ctx context.Context) (buckets []minio.BucketInfo, err error) {
+	ctx, span := c.startOperation(ctx, "ListBuckets")
+	defer func() { span.End(err) }()
+
+	c.logDebug(ctx, "[MinIO] Listing all buckets")
 
 	return c.minio.ListBuckets(ctx)
 }
 
 // GetBucketLocation gets the location of the configured bucket
-func (c *Client) GetBucketLocation(ctx context.Context) (string, error) {
-	rmlog.DebugCtxMin(ctx, "[MinIO] Getting bucket location",
+func (c *Client) GetBucketLocation(ctx context.Context) (location string, err error) {
+	ctx, span := c.startOperation(ctx, "GetBucketLocation")
+	defer func() { span.End(err) }()
+
+	c.logDebug(ctx, "[MinIO] Getting bucket location",
 		slog.String("bucket", c.bucketName))
 
 	return c.minio.GetBucketLocation(ctx, c.bucketName)
 }
 
 // GetBucketPolicy gets the bucket policy for the configured bucket
-func (c *Client) GetBucketPolicy(ctx context.Context) (string, error) {
-	rmlog.DebugCtxMin(ctx, "[MinIO] Getting bucket policy",
+func (c *Client) GetBucketPolicy(ctx context.Context) (policy string, err error) {
+	ctx, span := c.startOperation(ctx, "GetBucketPolicy")
+	defer func() { span.End(err) }()
+
+	c.logDebug(ctx, "[MinIO] Getting bucket policy",
 		slog.String("bucket", c.bucketName))
 
 	return c.minio.GetBucketPolicy(ctx, c.bucketName)
 }
 
 // SetBucketPolicy sets the bucket policy for the configured bucket
-func (c *Client) SetBucketPolicy(ctx context.Context, policy string) error {
-	rmlog.DebugCtxMin(ctx, "[MinIO] Setting bucket policy",
+func (c *Client) SetBucketPolicy(ctx context.Context, policy string) (err error) {
+	ctx, span := c.startOperation(ctx, "SetBucketPolicy")
+	defer func() { span.End(err) }()
+
+	c.logDebug(ctx, "[MinIO] Setting bucket policy",
 		slog.String("bucket", c.bucketName))
 
 	return c.minio.SetBucketPolicy(ctx, c.bucketName, policy)
 }
+
+// GetBucketVersioning gets the versioning configuration of the configured bucket
+func (c *Client) GetBucketVersioning(ctx context.Context) (config minio.BucketVersioningConfiguration, err error) {
+	ctx, span := c.startOperation(ctx, "GetBucketVersioning")
+	defer func() { span.End(err) }()
+
+	c.logDebug(ctx, "[MinIO] Getting bucket versioning",
+		slog.String("bucket", c.bucketName))
+
+	return c.minio.GetBucketVersioning(ctx, c.bucketName)
+}
+
+// EnableBucketVersioning enables versioning on the configured bucket
+func (c *Client) EnableBucketVersioning(ctx context.Context) (err error) {
+	ctx, span := c.startOperation(ctx, "EnableBucketVersioning")
+	defer func() { span.End(err) }()
+
+	c.logDebug(ctx, "[MinIO] Enabling bucket versioning",
+		slog.String("bucket", c.bucketName))
+
+	return c.minio.EnableVersioning(ctx, c.bucketName)
+}
+
+// SuspendBucketVersioning suspends versioning on the configured bucket
+func (c *Client) SuspendBucketVersioning(ctx context.Context) (err error) {
+	ctx, span := c.startOperation(ctx, "SuspendBucketVersioning")
+	defer func() { span.End(err) }()
+
+	c.logDebug(ctx, "[MinIO] Suspending bucket versioning",
+		slog.String("bucket", c.bucketName))
+
+	return c.minio.SuspendVersioning(ctx, c.bucketName)
+}
+
+// IsVersioningEnabled checks if versioning is currently enabled on the configured bucket
+func (c *Client) IsVersioningEnabled(ctx context.Context) (bool, error) {
+	config, err := c.GetBucketVersioning(ctx)
+	if err != nil {
+		return false, err
+	}
+	return config.Enabled(), nil
+}
+
+// GetBucketTagging gets the tags of the configured bucket
+func (c *Client) GetBucketTagging(ctx context.Context) (bucketTags *tags.Tags, err error) {
+	ctx, span := c.startOperation(ctx, "GetBucketTagging")
+	defer func() { span.End(err) }()
+
+	c.logDebug(ctx, "[MinIO] Getting bucket tags",
+		slog.String("bucket", c.bucketName))
+
+	return c.minio.GetBucketTagging(ctx, c.bucketName)
+}
+
+// SetBucketTagging sets the tags of the configured bucket, replacing any existing tags
+func (c *Client) SetBucketTagging(ctx context.Context, bucketTags *tags.Tags) (err error) {
+	ctx, span := c.startOperation(ctx, "SetBucketTagging")
+	defer func() { span.End(err) }()
+
+	c.logDebug(ctx, "[MinIO] Setting bucket tags",
+		slog.String("bucket", c.bucketName))
+
+	return c.minio.SetBucketTagging(ctx, c.bucketName, bucketTags)
+}
+
+// RemoveBucketTagging removes all tags from the configured bucket
+func (c *Client) RemoveBucketTagging(ctx context.Context) (err error) {
+	ctx, span := c.startOperation(ctx, "RemoveBucketTagging")
+	defer func() { span.End(err) }()
+
+	c.logDebug(ctx, "[MinIO] Removing bucket tags",
+		slog.String("bucket", c.bucketName))
+
+	return c.minio.RemoveBucketTagging(ctx, c.bucketName)
+}
+
+// GetBucketNotification gets the notification configuration of the configured bucket
+func (c *Client) GetBucketNotification(ctx context.Context) (config notification.Configuration, err error) {
+	ctx, span := c.startOperation(ctx, "GetBucketNotification")
+	defer func() { span.End(err) }()
+
+	c.logDebug(ctx, "[MinIO] Getting bucket notification",
+		slog.String("bucket", c.bucketName))
+
+	return c.minio.GetBucketNotification(ctx, c.bucketName)
+}
+
+// SetBucketNotification sets the notification configuration of the configured bucket
+func (c *Client) SetBucketNotification(ctx context.Context, config notification.Configuration) (err error) {
+	ctx, span := c.startOperation(ctx, "SetBucketNotification")
+	defer func() { span.End(err) }()
+
+	c.logDebug(ctx, "[MinIO] Setting bucket notification",
+		slog.String("bucket", c.bucketName))
+
+	return c.minio.SetBucketNotification(ctx, c.bucketName, config)
+}
+
+// RemoveAllBucketNotification removes all notification configurations from the configured bucket
+func (c *Client) RemoveAllBucketNotification(ctx context.Context) (err error) {
+	ctx, span := c.startOperation(ctx, "RemoveAllBucketNotification")
+	defer func() { span.End(err) }()
+
+	c.logDebug(ctx, "[MinIO] Removing all bucket notifications",
+		slog.String("bucket", c.bucketName))
+
+	return c.minio.RemoveAllBucketNotification(ctx, c.bucketName)
+}
+
+// MakeBucketWithLock creates a bucket with object locking enabled, which is required by the retention
+// and legal hold methods. Object locking can only be enabled when a bucket is created, and New requires
+// the configured bucket to exist, so the bucket to create is passed explicitly
+func (c *Client) MakeBucketWithLock(ctx context.Context, bucketName string, region string) (err error) {
+	ctx, span := c.startOperation(ctx, "MakeBucketWithLock", slog.String("newBucket", bucketName))
+	defer func() { span.End(err) }()
+
+	if bucketName == "" {
+		return fmt.Errorf("bucket name is required")
+	}
+
+	c.logDebug(ctx, "[MinIO] Creating bucket with object locking",
+		slog.String("bucket", bucketName),
+		slog.String("region", region))
+
+	_, err = withRetry(ctx, c, "MakeBucket", func() (struct{}, error) {
+		return struct{}{}, c.minio.MakeBucket(ctx, bucketName, minio.MakeBucketOptions{
+			Region:        region,
+			ObjectLocking: true,
+		})
+	})
+	return err
+}
+
+// SetObjectLockConfig sets the default retention applied to new objects in the configured bucket
+// The bucket must have been created with object locking enabled
+func (c *Client) SetObjectLockConfig(ctx context.Context, mode minio.RetentionMode, validity uint, unit minio.ValidityUnit) error {
+	return c.SetBucketObjectLockConfig(ctx, &mode, &validity, &unit)
+}
+
+// SetBucketObjectLockConfig sets the object lock configuration of the configured bucket
+// mode, validity and unit are either all set, to define the default retention, or all nil to remove it.
+// The bucket must have been created with object locking enabled (see MakeBucketWithLock)
+func (c *Client) SetBucketObjectLockConfig(ctx context.Context, mode *minio.RetentionMode, validity *uint, unit *minio.ValidityUnit) (err error) {
+	ctx, span := c.startOperation(ctx, "SetObjectLockConfig")
+	defer func() { span.End(err) }()
+
+	attrs := []slog.Attr{slog.String("bucket", c.bucketName)}
+	switch {
+	case mode == nil && validity == nil && unit == nil:
+	case mode == nil || validity == nil || unit == nil:
+		return fmt.Errorf("retention mode, validity and unit must be set together")
+	case !mode.IsValid():
+		return fmt.Errorf("invalid retention mode: %s", *mode)
+	case *unit != minio.Days && *unit != minio.Years:
+		return fmt.Errorf("invalid validity unit: %s", *unit)
+	default:
+		attrs = append(attrs,
+			slog.String("mode", mode.String()),
+			slog.Uint64("validity", uint64(*validity)),
+			slog.String("unit", unit.String()))
+	}
+
+	c.logDebug(ctx, "[MinIO] Setting object lock config", attrs...)
+
+	_, err = withRetry(ctx, c, "SetObjectLockConfig", func() (struct{}, error) {
+		return struct{}{}, c.minio.SetBucketObjectLockConfig(ctx, c.bucketName, mode, validity, unit)
+	})
+	return err
+}
+
+// GetBucketObjectLockConfig gets the default retention of the configured bucket
+// mode, validity and unit are nil when object locking is enabled without a default retention
+func (c *Client) GetBucketObjectLockConfig(ctx context.Context) (mode *minio.RetentionMode, validity *uint, unit *minio.ValidityUnit, err error) {
+	ctx, span := c.startOperation(ctx, "GetObjectLockConfig")
+	defer func() { span.End(err) }()
+
+	c.logDebug(ctx, "[MinIO] Getting object lock config",
+		slog.String("bucket", c.bucketName))
+
+	retention, err := withRetry(ctx, c, "GetObjectLockConfig", func() (defaultRetention, error) {
+		mode, validity, unit, err := c.minio.GetBucketObjectLockConfig(ctx, c.bucketName)
+		return defaultRetention{mode: mode, validity: validity, unit: unit}, err
+	})
+	return retention.mode, retention.validity, retention.unit, err
+}
+
+// defaultRetention holds the parts of a bucket default retention so they can be passed through withRetry
+type defaultRetention struct {
+	mode     *minio.RetentionMode
+	validity *uint
+	unit     *minio.ValidityUnit
+}
+
+// IsObjectLockEnabled reports whether object locking is enabled on the configured bucket
+func (c *Client) IsObjectLockEnabled(ctx context.Context) (enabled bool, err error) {
+	ctx, span := c.startOperation(ctx, "IsObjectLockEnabled")
+	defer func() { span.End(err) }()
+
+	c.logDebug(ctx, "[MinIO] Checking object lock",
+		slog.String("bucket", c.bucketName))
+
+	objectLock, err := withRetry(ctx, c, "IsObjectLockEnabled", func() (string, error) {
+		objectLock, _, _, _, err := c.minio.GetObjectLockConfig(ctx, c.bucketName)
+		return objectLock, err
+	})
+	if err != nil {
+		if minio.ToErrorResponse(err).Code == "ObjectLockConfigurationNotFoundError" {
+			return false, nil
+		}
+		return false, err
+	}
+	return objectLock == "Enabled", nil
+}
@@ -0,0 +1,101 @@
+package miniox
+
+import (
+	"context"
+	"testing"
+
+	"github.com/minio/minio-go/v7"
+)
+
+func TestBucketObjectLockConfigRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	c, fake := newFakeS3Client(t, "")
+
+	enabled, err := c.IsObjectLockEnabled(ctx)
+	if err != nil || enabled {
+		t.Fatalf("IsObjectLockEnabled before configuration = %v, %v, want false, nil", enabled, err)
+	}
+
+	// Object locking is enabled at bucket creation; the fake reports it once a configuration exists
+	fake.lockConfig = []byte(`<ObjectLockConfiguration><ObjectLockEnabled>Enabled</ObjectLockEnabled></ObjectLockConfiguration>`)
+
+	enabled, err = c.IsObjectLockEnabled(ctx)
+	if err != nil || !enabled {
+		t.Fatalf("IsObjectLockEnabled = %v, %v, want true, nil", enabled, err)
+	}
+
+	mode, validity, unit, err := c.GetBucketObjectLockConfig(ctx)
+	if err != nil {
+		t.Fatalf("GetBucketObjectLockConfig: %v", err)
+	}
+	if mode != nil || validity != nil || unit != nil {
+		t.Errorf("default retention = %v, %v, %v, want none", mode, validity, unit)
+	}
+
+	governance := minio.Governance
+	days := uint(30)
+	daysUnit := minio.Days
+	if err := c.SetBucketObjectLockConfig(ctx, &governance, &days, &daysUnit); err != nil {
+		t.Fatalf("SetBucketObjectLockConfig: %v", err)
+	}
+
+	mode, validity, unit, err = c.GetBucketObjectLockConfig(ctx)
+	if err != nil {
+		t.Fatalf("GetBucketObjectLockConfig: %v", err)
+	}
+	if mode == nil || *mode != minio.Governance || validity == nil || *validity != 30 || unit == nil || *unit != minio.Days {
+		t.Errorf("default retention = %v, %v, %v, want GOVERNANCE 30 DAYS", mode, validity, unit)
+	}
+
+	// The value form sets the same configuration
+	if err := c.SetObjectLockConfig(ctx, minio.Compliance, 1, minio.Years); err != nil {
+		t.Fatalf("SetObjectLockConfig: %v", err)
+	}
+	mode, validity, unit, _ = c.GetBucketObjectLockConfig(ctx)
+	if mode == nil || *mode != minio.Compliance || validity == nil || *validity != 1 || unit == nil || *unit != minio.Years {
+		t.Errorf("default retention = %v, %v, %v, want COMPLIANCE 1 YEARS", mode, validity, unit)
+	}
+
+	// All nil removes the default retention but keeps object locking enabled
+	if err := c.SetBucketObjectLockConfig(ctx, nil, nil, nil); err != nil {
+		t.Fatalf("SetBucketObjectLockConfig(nil): %v", err)
+	}
+	mode, validity, unit, err = c.GetBucketObjectLockConfig(ctx)
+	if err != nil || mode != nil || validity != nil || unit != nil {
+		t.Errorf("default retention after clearing = %v, %v, %v, %v, want none", mode, validity, unit, err)
+	}
+	if enabled, err := c.IsObjectLockEnabled(ctx); err != nil || !enabled {
+		t.Errorf("IsObjectLockEnabled after clearing = %v, %v, want true, nil", enabled, err)
+	}
+}
+
+func TestSetBucketObjectLockConfigValidation(t *testing.T) {
+	ctx := context.Background()
+	c := newTestClient(t, "")
+
+	governance := minio.Governance
+	invalidMode := minio.RetentionMode("FOREVER")
+	days := uint(1)
+	daysUnit := minio.Days
+	invalidUnit := minio.ValidityUnit("WEEKS")
+
+	tests := []struct {
+		name     string
+		mode     *minio.RetentionMode
+		validity *uint
+		unit     *minio.ValidityUnit
+	}{
+		{"missing unit", &governance, &days, nil},
+		{"missing mode", nil, &days, &daysUnit},
+		{"invalid mode", &invalidMode, &days, &daysUnit},
+		{"invalid unit", &governance, &days, &invalidUnit},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := c.SetBucketObjectLockConfig(ctx, tt.mode, tt.validity, tt.unit); err == nil {
+				t.Error("SetBucketObjectLockConfig accepted an invalid configuration")
+			}
+		})
+	}
+}
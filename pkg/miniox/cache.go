@@ -0,0 +1,147 @@
+package miniox
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+)
+
+// defaultStatCacheSize is the number of entries kept when a TTL is set without a size
+const defaultStatCacheSize = 1000
+
+// statCache is a concurrency-safe LRU cache of StatObject results with a fixed TTL
+// Entries are keyed by bucket and full object key, so scoped clients sharing the cache stay consistent
+type statCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	size    int
+	entries map[string]*list.Element
+	order   *list.List // Front is the most recently used entry
+}
+
+// statCacheEntry is a single cached StatObject result
+type statCacheEntry struct {
+	key       string
+	info      minio.ObjectInfo
+	expiresAt time.Time
+}
+
+// newStatCache creates a stat cache, or returns nil when caching is disabled (ttl <= 0)
+func newStatCache(ttl time.Duration, size int) *statCache {
+	if ttl <= 0 {
+		return nil
+	}
+	if size <= 0 {
+		size = defaultStatCacheSize
+	}
+
+	return &statCache{
+		ttl:     ttl,
+		size:    size,
+		entries: make(map[string]*list.Element),
+		order:   list.New(),
+	}
+}
+
+// get returns a fresh cached entry; a nil cache never has entries
+func (sc *statCache) get(key string) (minio.ObjectInfo, bool) {
+	if sc == nil {
+		return minio.ObjectInfo{}, false
+	}
+
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+
+	element, ok := sc.entries[key]
+	if !ok {
+		return minio.ObjectInfo{}, false
+	}
+
+	entry := element.Value.(*statCacheEntry)
+	if time.Now().After(entry.expiresAt) {
+		sc.order.Remove(element)
+		delete(sc.entries, key)
+		return minio.ObjectInfo{}, false
+	}
+
+	sc.order.MoveToFront(element)
+	return entry.info, true
+}
+
+// set stores an entry, evicting the least recently used entry when full
+func (sc *statCache) set(key string, info minio.ObjectInfo) {
+	if sc == nil {
+		return
+	}
+
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+
+	expiresAt := time.Now().Add(sc.ttl)
+	if element, ok := sc.entries[key]; ok {
+		entry := element.Value.(*statCacheEntry)
+		entry.info = info
+		entry.expiresAt = expiresAt
+		sc.order.MoveToFront(element)
+		return
+	}
+
+	sc.entries[key] = sc.order.PushFront(&statCacheEntry{key: key, info: info, expiresAt: expiresAt})
+
+	for sc.order.Len() > sc.size {
+		oldest := sc.order.Back()
+		sc.order.Remove(oldest)
+		delete(sc.entries, oldest.Value.(*statCacheEntry).key)
+	}
+}
+
+// delete removes an entry if present
+func (sc *statCache) delete(key string) {
+	if sc == nil {
+		return
+	}
+
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+
+	if element, ok := sc.entries[key]; ok {
+		sc.order.Remove(element)
+		delete(sc.entries, key)
+	}
+}
+
+// flush removes all entries
+func (sc *statCache) flush() {
+	if sc == nil {
+		return
+	}
+
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+
+	sc.entries = make(map[string]*list.Element)
+	sc.order.Init()
+}
+
+// statCacheKey returns the cache key for a full object key in the configured bucket
+func (c *Client) statCacheKey(fullPath string) string {
+	return c.bucketName + "/" + fullPath
+}
+
+// invalidateFullPath drops the cached stat result for a full object key
+func (c *Client) invalidateFullPath(fullPath string) {
+	c.statCache.delete(c.statCacheKey(fullPath))
+}
+
+// InvalidateCache drops the cached StatObject result for an object, resolved with the context prefix of ctx
+func (c *Client) InvalidateCache(ctx context.Context, objectPath string) {
+	c.invalidateFullPath(c.buildPath(ctx, objectPath))
+}
+
+// FlushCache drops all cached StatObject results
+func (c *Client) FlushCache() {
+	c.statCache.flush()
+}
@@ -0,0 +1,189 @@
+package miniox
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+)
+
+func TestNewStatCacheDisabled(t *testing.T) {
+	sc := newStatCache(0, 10)
+	if sc != nil {
+		t.Fatalf("newStatCache(0, 10) = %v, want nil", sc)
+	}
+
+	// A nil cache is usable and never holds entries
+	sc.set("b/key", minio.ObjectInfo{Key: "key"})
+	if _, ok := sc.get("b/key"); ok {
+		t.Error("disabled cache returned an entry")
+	}
+	sc.delete("b/key")
+	sc.flush()
+}
+
+func TestStatCacheLRUEviction(t *testing.T) {
+	sc := newStatCache(time.Minute, 2)
+
+	sc.set("a", minio.ObjectInfo{Key: "a"})
+	sc.set("b", minio.ObjectInfo{Key: "b"})
+
+	// Using "a" makes "b" the least recently used entry
+	if _, ok := sc.get("a"); !ok {
+		t.Fatal("entry a missing")
+	}
+	sc.set("c", minio.ObjectInfo{Key: "c"})
+
+	if _, ok := sc.get("b"); ok {
+		t.Error("least recently used entry b was not evicted")
+	}
+	for _, key := range []string{"a", "c"} {
+		if info, ok := sc.get(key); !ok || info.Key != key {
+			t.Errorf("get(%q) = %v, %v, want cached entry", key, info.Key, ok)
+		}
+	}
+
+	// Updating an existing entry does not grow the cache
+	sc.set("a", minio.ObjectInfo{Key: "a", Size: 42})
+	if info, _ := sc.get("a"); info.Size != 42 {
+		t.Errorf("updated entry size = %d, want 42", info.Size)
+	}
+	if sc.order.Len() != 2 || len(sc.entries) != 2 {
+		t.Errorf("cache holds %d/%d entries, want 2", sc.order.Len(), len(sc.entries))
+	}
+}
+
+func TestStatCacheDefaultSize(t *testing.T) {
+	if sc := newStatCache(time.Minute, 0); sc.size != defaultStatCacheSize {
+		t.Errorf("size = %d, want %d", sc.size, defaultStatCacheSize)
+	}
+}
+
+func TestStatCacheTTLExpiry(t *testing.T) {
+	sc := newStatCache(20*time.Millisecond, 10)
+	sc.set("a", minio.ObjectInfo{Key: "a"})
+
+	if _, ok := sc.get("a"); !ok {
+		t.Fatal("fresh entry missing")
+	}
+
+	time.Sleep(30 * time.Millisecond)
+
+	if _, ok := sc.get("a"); ok {
+		t.Error("expired entry was returned")
+	}
+	if len(sc.entries) != 0 {
+		t.Errorf("expired entry was not removed, %d entries left", len(sc.entries))
+	}
+}
+
+func TestStatCacheDeleteAndFlush(t *testing.T) {
+	sc := newStatCache(time.Minute, 10)
+	sc.set("a", minio.ObjectInfo{Key: "a"})
+	sc.set("b", minio.ObjectInfo{Key: "b"})
+
+	sc.delete("a")
+	if _, ok := sc.get("a"); ok {
+		t.Error("deleted entry was returned")
+	}
+
+	sc.flush()
+	if _, ok := sc.get("b"); ok {
+		t.Error("flushed entry was returned")
+	}
+}
+
+func TestStatCacheConcurrentUse(t *testing.T) {
+	sc := newStatCache(time.Minute, 8)
+
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			key := string(rune('a' + i))
+			for range 200 {
+				sc.set(key, minio.ObjectInfo{Key: key})
+				sc.get(key)
+				sc.delete(key)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestStatObjectCacheInvalidation(t *testing.T) {
+	ctx := context.Background()
+	c, fake := newFakeS3Client(t, "app-data")
+	c.statCache = newStatCache(time.Minute, 10)
+
+	fake.put("app-data/doc.txt", []byte("v1"), nil)
+	fake.put("app-data/src.txt", []byte("source content"), nil)
+
+	stat := func(objectPath string) (minio.ObjectInfo, error) {
+		t.Helper()
+		return c.StatObject(ctx, objectPath, minio.StatObjectOptions{})
+	}
+
+	info, err := stat("doc.txt")
+	if err != nil || info.Size != 2 || info.Key != "doc.txt" {
+		t.Fatalf("StatObject = %+v, %v", info, err)
+	}
+
+	// Served from the cache: a write behind the client's back is not seen
+	fake.put("app-data/doc.txt", []byte("changed"), nil)
+	if info, _ := stat("doc.txt"); info.Size != 2 {
+		t.Errorf("cached size = %d, want 2", info.Size)
+	}
+	if got := fake.requestCount("HEAD", "app-data/doc.txt"); got != 1 {
+		t.Errorf("HEAD requests = %d, want 1", got)
+	}
+
+	// Put invalidates
+	data := []byte("version three")
+	if _, err := c.PutObject(ctx, "doc.txt", bytes.NewReader(data), int64(len(data)), minio.PutObjectOptions{}); err != nil {
+		t.Fatalf("PutObject: %v", err)
+	}
+	if info, _ := stat("doc.txt"); info.Size != int64(len(data)) {
+		t.Errorf("size after PutObject = %d, want %d", info.Size, len(data))
+	}
+
+	// Copy invalidates the destination
+	if _, err := c.CopyObject(ctx, "doc.txt", "src.txt", minio.CopyDestOptions{}); err != nil {
+		t.Fatalf("CopyObject: %v", err)
+	}
+	if info, _ := stat("doc.txt"); info.Size != int64(len("source content")) {
+		t.Errorf("size after CopyObject = %d, want %d", info.Size, len("source content"))
+	}
+
+	// Remove invalidates
+	if err := c.RemoveObject(ctx, "doc.txt", minio.RemoveObjectOptions{}); err != nil {
+		t.Fatalf("RemoveObject: %v", err)
+	}
+	if _, err := stat("doc.txt"); minio.ToErrorResponse(err).Code != "NoSuchKey" {
+		t.Errorf("StatObject after RemoveObject error = %v, want NoSuchKey", err)
+	}
+}
+
+func TestStatObjectCacheExpiry(t *testing.T) {
+	ctx := context.Background()
+	c, fake := newFakeS3Client(t, "")
+	c.statCache = newStatCache(50*time.Millisecond, 10)
+
+	fake.put("doc.txt", []byte("v1"), nil)
+	if _, err := c.StatObject(ctx, "doc.txt", minio.StatObjectOptions{}); err != nil {
+		t.Fatalf("StatObject: %v", err)
+	}
+
+	fake.put("doc.txt", []byte(strings.Repeat("x", 10)), nil)
+	time.Sleep(60 * time.Millisecond)
+
+	info, err := c.StatObject(ctx, "doc.txt", minio.StatObjectOptions{})
+	if err != nil || info.Size != 10 {
+		t.Errorf("StatObject after TTL = %d, %v, want size 10", info.Size, err)
+	}
+}
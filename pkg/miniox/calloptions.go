@@ -0,0 +1,79 @@
+package miniox
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+)
+
+// CallOption overrides the client retry configuration for a single call
+type CallOption func(*RetryConfig)
+
+// callOptionsKey is the context key holding per-call options
+type callOptionsKey struct{}
+
+// WithRetries sets the number of retries after the first attempt; WithRetries(0) disables retries
+func WithRetries(retries int) CallOption {
+	return func(r *RetryConfig) {
+		r.MaxAttempts = max(retries, 0) + 1
+	}
+}
+
+// WithBackoff sets the delay before the first retry and the upper bound for the delay between attempts
+// Zero values keep the client setting
+func WithBackoff(initial, maxBackoff time.Duration) CallOption {
+	return func(r *RetryConfig) {
+		if initial > 0 {
+			r.InitialBackoff = initial
+		}
+		if maxBackoff > 0 {
+			r.MaxBackoff = maxBackoff
+		}
+	}
+}
+
+// WithRetryableCodes replaces the S3 error codes considered transient
+func WithRetryableCodes(codes ...string) CallOption {
+	return func(r *RetryConfig) {
+		r.RetryableCodes = codes
+	}
+}
+
+// WithCallOptions returns a context that applies the options to every client call made with it
+// Options are added to those already in ctx. Only idempotent operations are ever retried, and uploads only
+// when the reader can be rewound
+func WithCallOptions(ctx context.Context, opts ...CallOption) context.Context {
+	existing, _ := ctx.Value(callOptionsKey{}).([]CallOption)
+	combined := make([]CallOption, 0, len(existing)+len(opts))
+	combined = append(combined, existing...)
+	combined = append(combined, opts...)
+	return context.WithValue(ctx, callOptionsKey{}, combined)
+}
+
+// StatObjectWithOptions performs StatObject with per-call retry options
+func (c *Client) StatObjectWithOptions(ctx context.Context, objectPath string, opts minio.StatObjectOptions, callOpts ...CallOption) (minio.ObjectInfo, error) {
+	return c.StatObject(WithCallOptions(ctx, callOpts...), objectPath, opts)
+}
+
+// GetObjectWithOptions opens an object like OpenObject with per-call retry options
+// The initial request is made eagerly so it can be retried; the caller must close the returned reader
+func (c *Client) GetObjectWithOptions(ctx context.Context, objectPath string, opts minio.GetObjectOptions, callOpts ...CallOption) (io.ReadCloser, minio.ObjectInfo, error) {
+	return c.OpenObject(WithCallOptions(ctx, callOpts...), objectPath, opts)
+}
+
+// PutObjectWithOptions performs PutObject with per-call retry options
+func (c *Client) PutObjectWithOptions(ctx context.Context, objectPath string, reader io.Reader, objectSize int64, opts minio.PutObjectOptions, callOpts ...CallOption) (minio.UploadInfo, error) {
+	return c.PutObject(WithCallOptions(ctx, callOpts...), objectPath, reader, objectSize, opts)
+}
+
+// CopyObjectWithOptions performs CopyObject with per-call retry options
+func (c *Client) CopyObjectWithOptions(ctx context.Context, destObjectPath string, srcObjectPath string, opts minio.CopyDestOptions, callOpts ...CallOption) (minio.UploadInfo, error) {
+	return c.CopyObject(WithCallOptions(ctx, callOpts...), destObjectPath, srcObjectPath, opts)
+}
+
+// RemoveObjectWithOptions performs RemoveObject with per-call retry options
+func (c *Client) RemoveObjectWithOptions(ctx context.Context, objectPath string, opts minio.RemoveObjectOptions, callOpts ...CallOption) error {
+	return c.RemoveObject(WithCallOptions(ctx, callOpts...), objectPath, opts)
+}
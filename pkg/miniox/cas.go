@@ -0,0 +1,74 @@
+package miniox
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"log/slog"
+	"strings"
+
+	"github.com/minio/minio-go/v7"
+)
+
+// ContentAddressedPath returns the object path for data stored by PutObjectContentAddressed
+// The key is <prefix>/<h[0:2]>/<h[2:4]>/<h> where h is the hex SHA-256 of the data; the two-level fan-out
+// keeps each folder small enough to list
+func ContentAddressedPath(prefix string, data []byte) string {
+	sum := sha256.Sum256(data)
+	hash := hex.EncodeToString(sum[:])
+
+	key := hash[0:2] + "/" + hash[2:4] + "/" + hash
+	if cleanPrefix := strings.Trim(prefix, "/"); cleanPrefix != "" {
+		return cleanPrefix + "/" + key
+	}
+	return key
+}
+
+// PutObjectContentAddressed stores data under a key derived from its SHA-256 hash and returns the relative path
+// The upload is skipped when the object already exists; in that case the returned info describes the stored object
+func (c *Client) PutObjectContentAddressed(ctx context.Context, prefix string, data []byte, opts minio.PutObjectOptions) (objectPath string, uploadInfo minio.UploadInfo, err error) {
+	if err := c.validatePath(ctx, prefix); err != nil {
+		return "", minio.UploadInfo{}, err
+	}
+
+	objectPath = ContentAddressedPath(prefix, data)
+
+	info, err := c.StatObject(ctx, objectPath, minio.StatObjectOptions{})
+	if err == nil {
+		c.logDebug(ctx, "[MinIO] Content-addressed object already exists",
+			slog.String("bucket", c.bucketName),
+			slog.String("object", objectPath))
+		return objectPath, uploadInfoFromObjectInfo(c.bucketName, info), nil
+	}
+	if minio.ToErrorResponse(err).Code != "NoSuchKey" {
+		return "", minio.UploadInfo{}, err
+	}
+
+	uploadInfo, created, err := c.PutObjectIfNotExists(ctx, objectPath, bytes.NewReader(data), int64(len(data)), opts)
+	if err != nil {
+		return "", minio.UploadInfo{}, err
+	}
+	if !created {
+		// Stored concurrently with the same content
+		info, err := c.StatObject(ctx, objectPath, minio.StatObjectOptions{})
+		if err != nil {
+			return "", minio.UploadInfo{}, err
+		}
+		return objectPath, uploadInfoFromObjectInfo(c.bucketName, info), nil
+	}
+
+	return objectPath, uploadInfo, nil
+}
+
+// uploadInfoFromObjectInfo describes an existing object as the result of an upload
+func uploadInfoFromObjectInfo(bucketName string, info minio.ObjectInfo) minio.UploadInfo {
+	return minio.UploadInfo{
+		Bucket:       bucketName,
+		Key:          info.Key,
+		ETag:         info.ETag,
+		Size:         info.Size,
+		LastModified: info.LastModified,
+		VersionID:    info.VersionID,
+	}
+}
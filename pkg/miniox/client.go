@@ -4,60 +4,149 @@ package miniox
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
-	"path/filepath"
+	"slices"
 	"strings"
+	"time"
 
-	"github.com/aeternitas-infinita/rmlog"
 	"github.com/minio/minio-go/v7"
 	"github.com/minio/minio-go/v7/pkg/credentials"
+	"github.com/minio/minio-go/v7/pkg/encrypt"
 )
 
 // Config represents the configuration for MinIO client initialization
 type Config struct {
 	Endpoint      string // MinIO server endpoint (e.g., "localhost:9000")
-	AccessKey     string // Access key for authentication
-	SecretKey     string // Secret key for authentication
+	AccessKey     string // Access key for authentication (unused when Anonymous is set)
+	SecretKey     string // Secret key for authentication (unused when Anonymous is set)
 	UseSSL        bool   // Whether to use HTTPS
 	BucketName    string // Default bucket name for operations
 	BaseDirPrefix string // Optional: Base directory prefix for all operations
 	PublicURL     string // Optional: Public URL for generating accessible links
+	Tracer        Tracer // Optional: Tracer for spans around client operations
+
+	AutoDetectContentType  bool               // Optional: Detect content type on upload when none is provided
+	DefaultSSE             encrypt.ServerSide // Optional: Server-side encryption applied to writes that don't set one
+	TrashPrefix            string             // Optional: Prefix for soft-deleted objects (default ".trash")
+	NotificationRetries    int                // Optional: Reconnect attempts for notification streams (default 5, negative disables)
+	StatCacheTTL           time.Duration      // Optional: Cache StatObject results for this long (disabled when zero)
+	StatCacheSize          int                // Optional: Maximum number of cached StatObject results (default 1000)
+	Retry                  RetryConfig        // Optional: Retry policy for transient errors (disabled by default)
+	OperationTimeout       time.Duration      // Optional: Timeout applied to operations whose context has no deadline
+	OpsPerSecond           float64            // Optional: Maximum requests per second across all operations (unlimited when zero)
+	MaxUploadBytesPerSec   int64              // Optional: Maximum upload throughput in bytes per second (unlimited when zero)
+	MaxDownloadBytesPerSec int64              // Optional: Maximum download throughput in bytes per second (unlimited when zero)
+	MaxBytesPerSecond      int64              // Optional: Maximum combined upload and download throughput in bytes per second (unlimited when zero)
+	MetricsObserver        MetricsObserver    // Optional: Receives operation latencies, errors and transferred bytes
+	MaxObjectSize          int64              // Optional: Maximum size in bytes of uploaded objects (unlimited when zero)
+	AllowedContentTypes    []string           // Optional: Content types allowed for uploads, "image/" style entries match by prefix
+	KeyGenerator           KeyGenerator       // Optional: Key scheme for PutObjectWithGeneratedKey (default "<yyyy>/<mm>/<dd>/<uuid><ext>")
+	LogLevel               slog.Leveler       // Optional: Minimum level of client log lines (default: all lines are passed on)
+	DisableLogging         bool               // Optional: Suppress all client log lines
+	Logger                 *slog.Logger       // Optional: Logger for client log lines (default: the package-wide rmlog logger)
+	Name                   string             // Optional: Client name added to log lines as "client" to tell several clients apart
+	MaxSortedListObjects   int                // Optional: Maximum number of objects ListObjectsSorted collects before failing (default 100000)
+	DefaultStorageClass    string             // Optional: Storage class applied to uploads that don't set one (STANDARD or REDUCED_REDUNDANCY)
+	Anonymous              bool               // Optional: Send unsigned requests without credentials, e.g. for read-only access to public buckets
+	EnableChecksums        bool               // Optional: Send upload checksums in trailing headers (CRC32C by default), required by PutObjectWithChecksum
 }
 
 // Client represents an extended MinIO client with additional functionality
 type Client struct {
 	minio         *minio.Client
+	transport     *clientTransport
 	bucketName    string
 	baseDirPrefix string
 	publicBaseURL string
+	tracer        Tracer
+
+	autoDetectContentType bool
+	defaultSSE            encrypt.ServerSide
+	trashPrefix           string
+	notificationRetries   int
+	statCache             *statCache
+	retry                 RetryConfig
+	operationTimeout      time.Duration
+	rateLimiter           *rateLimiter
+	metrics               MetricsObserver
+	uploadLimits          UploadLimits
+	keyGenerator          KeyGenerator
+	logLevel              slog.Leveler
+	disableLogging        bool
+	logger                *slog.Logger
+	name                  string
+	maxSortedListObjects  int
+	defaultStorageClass   string
+	checksums             bool
 }
 
-// New creates and initializes a new MinIO extended client
-func New(config *Config) (*Client, error) {
+// Validate checks the configuration without connecting to the server
+// All problems are reported at once, joined with errors.Join (e.g. a missing endpoint and a missing bucket name)
+func (config *Config) Validate() error {
 	if config == nil {
-		return nil, fmt.Errorf("config cannot be nil")
+		return fmt.Errorf("config cannot be nil")
 	}
 
+	var errs []error
+
 	if config.Endpoint == "" {
-		return nil, fmt.Errorf("endpoint is required")
+		errs = append(errs, fmt.Errorf("endpoint is required"))
 	}
 
-	if config.AccessKey == "" {
-		return nil, fmt.Errorf("access key is required")
+	if !config.Anonymous && config.AccessKey == "" {
+		errs = append(errs, fmt.Errorf("access key is required"))
 	}
 
-	if config.SecretKey == "" {
-		return nil, fmt.Errorf("secret key is required")
+	if !config.Anonymous && config.SecretKey == "" {
+		errs = append(errs, fmt.Errorf("secret key is required"))
 	}
 
 	if config.BucketName == "" {
-		return nil, fmt.Errorf("bucket name is required")
+		errs = append(errs, fmt.Errorf("bucket name is required"))
+	}
+
+	if config.DefaultStorageClass != "" {
+		if err := validateStorageClass(config.DefaultStorageClass); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// New creates and initializes a new MinIO extended client
+// The configuration is checked with Config.Validate before connecting
+func New(config *Config) (*Client, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	transport, err := minio.DefaultTransport(config.UseSSL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create MinIO transport: %w", err)
+	}
+
+	limiter := &rateLimiter{}
+	limiter.set(RateLimits{
+		OpsPerSecond:           config.OpsPerSecond,
+		MaxUploadBytesPerSec:   config.MaxUploadBytesPerSec,
+		MaxDownloadBytesPerSec: config.MaxDownloadBytesPerSec,
+		MaxBytesPerSecond:      config.MaxBytesPerSecond,
+	})
+
+	creds := credentials.NewStaticV4(config.AccessKey, config.SecretKey, "")
+	if config.Anonymous {
+		creds = credentials.NewStatic("", "", "", credentials.SignatureAnonymous)
 	}
 
+	roundTripper := &clientTransport{base: transport, limiter: limiter, metrics: config.MetricsObserver}
 	client, err := minio.New(config.Endpoint, &minio.Options{
-		Creds:  credentials.NewStaticV4(config.AccessKey, config.SecretKey, ""),
-		Secure: config.UseSSL,
+		Creds:           creds,
+		Secure:          config.UseSSL,
+		Transport:       roundTripper,
+		TrailingHeaders: config.EnableChecksums,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to create MinIO client: %w", err)
@@ -65,6 +154,10 @@ func New(config *Config) (*Client, error) {
 
 	// Check if bucket exists
 	exists, err := client.BucketExists(context.Background(), config.BucketName)
+	if err != nil && config.Anonymous && minio.ToErrorResponse(err).Code == "AccessDenied" {
+		// Public buckets rarely allow anonymous HEAD requests; a denied request still proves the bucket exists
+		exists, err = true, nil
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to check bucket existence: %w", err)
 	}
@@ -73,14 +166,53 @@ func New(config *Config) (*Client, error) {
 		return nil, fmt.Errorf("bucket %s does not exist", config.BucketName)
 	}
 
+	trashPrefix := strings.Trim(toSlash(config.TrashPrefix), "/")
+	if trashPrefix == "" {
+		trashPrefix = defaultTrashPrefix
+	}
+
+	notificationRetries := config.NotificationRetries
+	if notificationRetries == 0 {
+		notificationRetries = defaultNotificationRetries
+	}
+
+	maxSortedListObjects := config.MaxSortedListObjects
+	if maxSortedListObjects <= 0 {
+		maxSortedListObjects = defaultMaxSortedListObjects
+	}
+
 	extendedClient := &Client{
 		minio:         client,
+		transport:     roundTripper,
 		bucketName:    config.BucketName,
 		baseDirPrefix: config.BaseDirPrefix,
 		publicBaseURL: config.PublicURL,
+		tracer:        config.Tracer,
+
+		autoDetectContentType: config.AutoDetectContentType,
+		defaultSSE:            config.DefaultSSE,
+		trashPrefix:           trashPrefix,
+		notificationRetries:   notificationRetries,
+		statCache:             newStatCache(config.StatCacheTTL, config.StatCacheSize),
+		retry:                 normalizeRetryConfig(config.Retry),
+		operationTimeout:      config.OperationTimeout,
+		rateLimiter:           limiter,
+		metrics:               config.MetricsObserver,
+		uploadLimits: UploadLimits{
+			MaxObjectSize:       config.MaxObjectSize,
+			AllowedContentTypes: config.AllowedContentTypes,
+		},
+		keyGenerator:         config.KeyGenerator,
+		logLevel:             config.LogLevel,
+		disableLogging:       config.DisableLogging,
+		logger:               config.Logger,
+		name:                 config.Name,
+		maxSortedListObjects: maxSortedListObjects,
+		defaultStorageClass:  config.DefaultStorageClass,
+		checksums:            config.EnableChecksums,
 	}
 
-	rmlog.InfoMin("[MinIO] successfully connected to MinIO",
+	extendedClient.logInfo("[MinIO] successfully connected to MinIO",
 		slog.String("endpoint", config.Endpoint),
 		slog.String("bucket", config.BucketName))
 
@@ -102,23 +234,97 @@ func (c *Client) GetPublicBaseURL() string {
 	return c.publicBaseURL
 }
 
-// buildPath constructs the full path with base directory prefix
+// WithPrefix returns a client scoped to a sub-prefix of the current base directory prefix
+// The returned client shares the underlying MinIO connection and configuration, so it is cheap to create
+// (e.g. client.WithPrefix("tenants/" + tenantID)). All paths on the scoped client are relative to the nested prefix
+func (c *Client) WithPrefix(subPrefix string) (*Client, error) {
+	if err := c.ValidatePath(subPrefix); err != nil {
+		return nil, err
+	}
+
+	cleanSubPrefix := cleanKeyPath(subPrefix)
+	if cleanSubPrefix == "" {
+		return nil, fmt.Errorf("sub-prefix cannot be empty")
+	}
+
+	scoped := *c
+	scoped.baseDirPrefix = c.buildPath(context.Background(), cleanSubPrefix)
+	return &scoped, nil
+}
+
+// contextPrefixKey is the context key holding a request-scoped sub-prefix
+type contextPrefixKey struct{}
+
+// WithContextPrefix returns a context carrying a request-scoped sub-prefix (e.g. "tenants/42")
+// Every operation receiving the context joins the prefix under the base directory prefix and returns keys relative
+// to it. The prefix is validated by the operations, so middleware can set it unconditionally
+func WithContextPrefix(ctx context.Context, prefix string) context.Context {
+	return context.WithValue(ctx, contextPrefixKey{}, prefix)
+}
+
+// ContextPrefix returns the sub-prefix set with WithContextPrefix and whether one is set
+func ContextPrefix(ctx context.Context) (string, bool) {
+	prefix, ok := ctx.Value(contextPrefixKey{}).(string)
+	return prefix, ok
+}
+
+// contextSubPrefix returns the cleaned sub-prefix carried by ctx, or "" when none is set
+// An empty prefix is rejected rather than ignored, so a missing tenant ID cannot widen the scope to the whole base directory
+func contextSubPrefix(ctx context.Context) (string, error) {
+	prefix, ok := ContextPrefix(ctx)
+	if !ok {
+		return "", nil
+	}
+
+	cleanPrefix := toSlash(prefix)
+	if strings.Contains(cleanPrefix, "..") {
+		return "", fmt.Errorf("path traversal detected in context prefix: %s", prefix)
+	}
+	if strings.HasPrefix(cleanPrefix, "/") {
+		return "", fmt.Errorf("absolute context prefix is not allowed: %s", prefix)
+	}
+
+	cleanPrefix = cleanKeyPath(cleanPrefix)
+	if cleanPrefix == "" {
+		return "", fmt.Errorf("context prefix cannot be empty")
+	}
+	return cleanPrefix, nil
+}
+
+// keyPrefix returns the cleaned prefix all keys of an operation live under: the base directory prefix joined with the
+// context prefix of ctx. An invalid context prefix is still joined as-is; operations reject it through validatePath
+func (c *Client) keyPrefix(ctx context.Context) string {
+	prefix := cleanKeyPath(c.baseDirPrefix)
+
+	subPrefix, err := contextSubPrefix(ctx)
+	if err != nil {
+		rawPrefix, _ := ContextPrefix(ctx)
+		subPrefix = cleanKeyPath(rawPrefix)
+	}
+
+	if subPrefix == "" {
+		return prefix
+	}
+	if prefix == "" {
+		return subPrefix
+	}
+	return prefix + "/" + subPrefix
+}
+
+// buildPath constructs the full path with base directory prefix and the context prefix of ctx
 // Ensures proper forward slash formatting for MinIO compatibility
-func (c *Client) buildPath(path string) string {
-	// Clean the input path: remove leading/trailing slashes and convert to forward slashes
-	cleanPath := strings.Trim(filepath.ToSlash(path), "/")
-
-	// If no base directory prefix is set, return the clean path
-	if c.baseDirPrefix == "" {
-		if cleanPath == "" {
-			return ""
-		}
+func (c *Client) buildPath(ctx context.Context, path string) string {
+	// Clean the input path: convert to forward slashes, collapse repeated slashes and remove leading/trailing ones
+	cleanPath := cleanKeyPath(path)
+
+	// Clean the prefix the same way
+	cleanPrefix := c.keyPrefix(ctx)
+
+	// If no prefix is set, return the clean path
+	if cleanPrefix == "" {
 		return cleanPath
 	}
 
-	// Clean the base directory prefix: remove leading/trailing slashes and convert to forward slashes
-	cleanPrefix := strings.Trim(filepath.ToSlash(c.baseDirPrefix), "/")
-
 	// If the clean path is empty, return just the prefix
 	if cleanPath == "" {
 		return cleanPrefix
@@ -130,15 +336,51 @@ func (c *Client) buildPath(path string) string {
 	return fullPath
 }
 
-// stripBasePath removes the base directory prefix from a full path
+// toSlash converts backslashes to forward slashes regardless of the OS the server runs on
+// filepath.ToSlash only converts on Windows, so a "docs\report.pdf" sent by a Windows client would otherwise
+// keep the backslash as part of the key on Linux
+func toSlash(path string) string {
+	return strings.ReplaceAll(path, "\\", "/")
+}
+
+// cleanKeyPath converts a path to forward slashes, collapses runs of slashes into one and trims leading and
+// trailing slashes, so "docs//2024///report.pdf" and "/docs/2024/report.pdf/" yield the same key
+func cleanKeyPath(path string) string {
+	segments := strings.Split(toSlash(path), "/")
+	return strings.Join(slices.DeleteFunc(segments, func(segment string) bool { return segment == "" }), "/")
+}
+
+// buildFolderPath constructs the full folder prefix with base directory prefix
+// The result always ends with a slash ("images", "images/" and "/images" all yield "<base>/images/"),
+// except for the bucket root without a base directory prefix, which is the empty string
+func (c *Client) buildFolderPath(ctx context.Context, folderPath string) string {
+	fullPath := c.buildPath(ctx, folderPath)
+	if fullPath == "" {
+		return ""
+	}
+	return fullPath + "/"
+}
+
+// buildPrefix constructs a listing prefix with the base directory prefix
+// A trailing slash on the input is kept so "docs/" only matches objects inside the docs folder,
+// and an empty input lists the contents of the base directory rather than its siblings
+func (c *Client) buildPrefix(ctx context.Context, prefix string) string {
+	if prefix == "" || strings.HasSuffix(toSlash(prefix), "/") {
+		return c.buildFolderPath(ctx, prefix)
+	}
+
+	return c.buildPath(ctx, prefix)
+}
+
+// stripBasePath removes the base directory prefix and the context prefix of ctx from a full path
 // This is useful when returning paths to external callers who expect relative paths
-func (c *Client) stripBasePath(fullPath string) string {
-	if c.baseDirPrefix == "" {
+func (c *Client) stripBasePath(ctx context.Context, fullPath string) string {
+	cleanPrefix := c.keyPrefix(ctx)
+	if cleanPrefix == "" {
 		return fullPath
 	}
 
-	cleanPrefix := strings.Trim(filepath.ToSlash(c.baseDirPrefix), "/")
-	cleanFullPath := filepath.ToSlash(fullPath)
+	cleanFullPath := toSlash(fullPath)
 
 	// Check if the full path starts with the prefix
 	if strings.HasPrefix(cleanFullPath, cleanPrefix+"/") {
@@ -151,9 +393,17 @@ func (c *Client) stripBasePath(fullPath string) string {
 	return cleanFullPath
 }
 
+// validatePath validates a path like ValidatePath and additionally rejects an invalid context prefix on ctx
+func (c *Client) validatePath(ctx context.Context, path string) error {
+	if _, err := contextSubPrefix(ctx); err != nil {
+		return err
+	}
+	return c.ValidatePath(path)
+}
+
 // ValidatePath ensures the path is safe and doesn't try to escape the base directory
 func (c *Client) ValidatePath(path string) error {
-	cleanPath := filepath.ToSlash(path)
+	cleanPath := toSlash(path)
 
 	// Check for path traversal attempts
 	if strings.Contains(cleanPath, "..") {
@@ -168,6 +418,15 @@ func (c *Client) ValidatePath(path string) error {
 	return nil
 }
 
+// Close releases the idle connections of the underlying HTTP connection pool
+// The pool is shared with every client derived via WithPrefix. Closing is optional and idempotent:
+// the client stays usable afterwards and simply opens new connections, so Close only needs to be called when
+// clients are created and discarded repeatedly
+func (c *Client) Close() error {
+	c.transport.CloseIdleConnections()
+	return nil
+}
+
 // GetRawClient returns the underlying MinIO client for direct access
 // This allows users to access any native MinIO functionality that may not be wrapped
 // by the extended client. Use with caution as operations performed directly on the
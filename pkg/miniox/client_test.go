@@ -0,0 +1,214 @@
+package miniox
+
+import (
+	"bytes"
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+// newTestClient returns a client for tests that never reach a server; minio.New does not dial
+func newTestClient(t *testing.T, baseDirPrefix string) *Client {
+	t.Helper()
+
+	mc, err := minio.New("localhost:9000", &minio.Options{
+		Creds:  credentials.NewStaticV4("access", "secret", ""),
+		Region: "us-east-1",
+	})
+	if err != nil {
+		t.Fatalf("minio.New: %v", err)
+	}
+
+	return &Client{
+		minio:         mc,
+		bucketName:    "test-bucket",
+		baseDirPrefix: baseDirPrefix,
+		retry:         normalizeRetryConfig(RetryConfig{}),
+	}
+}
+
+func TestBuildPathCollapsesSlashes(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name          string
+		baseDirPrefix string
+		path          string
+		want          string
+		wantFolder    string
+	}{
+		{"plain", "", "docs/report.pdf", "docs/report.pdf", "docs/report.pdf/"},
+		{"doubled", "", "docs//report.pdf", "docs/report.pdf", "docs/report.pdf/"},
+		{"tripled", "", "docs///2024///report.pdf", "docs/2024/report.pdf", "docs/2024/report.pdf/"},
+		{"leading and trailing", "", "//docs/2024//", "docs/2024", "docs/2024/"},
+		{"only slashes", "", "///", "", ""},
+		{"empty", "", "", "", ""},
+		{"with prefix", "app-data", "docs//2024///report.pdf", "app-data/docs/2024/report.pdf", "app-data/docs/2024/report.pdf/"},
+		{"prefix with duplicate slashes", "/app//data/", "docs/report.pdf", "app/data/docs/report.pdf", "app/data/docs/report.pdf/"},
+		{"prefix only", "app-data", "//", "app-data", "app-data/"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t, tt.baseDirPrefix)
+
+			if got := c.buildPath(ctx, tt.path); got != tt.want {
+				t.Errorf("buildPath(%q) = %q, want %q", tt.path, got, tt.want)
+			}
+			if got := c.buildFolderPath(ctx, tt.path); got != tt.wantFolder {
+				t.Errorf("buildFolderPath(%q) = %q, want %q", tt.path, got, tt.wantFolder)
+			}
+		})
+	}
+}
+
+func TestStripBasePathRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	paths := []string{
+		"report.pdf",
+		"docs//report.pdf",
+		"docs///2024///report.pdf",
+		"/docs/2024/report.pdf/",
+	}
+
+	for _, baseDirPrefix := range []string{"", "app-data", "app//data/"} {
+		c := newTestClient(t, baseDirPrefix)
+		for _, p := range paths {
+			want := cleanKeyPath(p)
+			if got := c.stripBasePath(ctx, c.buildPath(ctx, p)); got != want {
+				t.Errorf("prefix %q: stripBasePath(buildPath(%q)) = %q, want %q", baseDirPrefix, p, got, want)
+			}
+		}
+	}
+}
+
+func TestBackslashPaths(t *testing.T) {
+	ctx := context.Background()
+	c := newTestClient(t, "app-data")
+
+	tests := []struct {
+		path string
+		want string
+	}{
+		{`a\b`, "app-data/a/b"},
+		{`docs\2024\report.pdf`, "app-data/docs/2024/report.pdf"},
+		{`\docs\\report.pdf\`, "app-data/docs/report.pdf"},
+		{`docs/mixed\report.pdf`, "app-data/docs/mixed/report.pdf"},
+	}
+
+	for _, tt := range tests {
+		if got := c.buildPath(ctx, tt.path); got != tt.want {
+			t.Errorf("buildPath(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+
+	if got := toSlash(`a\b`); got != "a/b" {
+		t.Errorf(`toSlash("a\b") = %q, want "a/b"`, got)
+	}
+}
+
+func TestValidatePathRejectsBackslashTraversal(t *testing.T) {
+	c := newTestClient(t, "")
+
+	for _, p := range []string{`..\x`, `docs\..\..\x`, `\x`, `\\server\share`} {
+		if err := c.ValidatePath(p); err == nil {
+			t.Errorf("ValidatePath(%q) = nil, want error", p)
+		}
+	}
+
+	for _, p := range []string{`a\b`, `docs\report.pdf`} {
+		if err := c.ValidatePath(p); err != nil {
+			t.Errorf("ValidatePath(%q) = %v, want nil", p, err)
+		}
+	}
+}
+
+func TestContextPrefixPaths(t *testing.T) {
+	tests := []struct {
+		name          string
+		baseDirPrefix string
+		contextPrefix string
+		path          string
+		want          string
+	}{
+		{"without base prefix", "", "tenants/42", "docs/report.pdf", "tenants/42/docs/report.pdf"},
+		{"with base prefix", "app-data", "tenants/42", "docs/report.pdf", "app-data/tenants/42/docs/report.pdf"},
+		{"messy context prefix", "app-data", `tenants\\42//`, "docs/report.pdf", "app-data/tenants/42/docs/report.pdf"},
+		{"empty path", "app-data", "tenants/42", "", "app-data/tenants/42"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t, tt.baseDirPrefix)
+			ctx := WithContextPrefix(context.Background(), tt.contextPrefix)
+
+			if err := c.validatePath(ctx, tt.path); err != nil {
+				t.Fatalf("validatePath: %v", err)
+			}
+			got := c.buildPath(ctx, tt.path)
+			if got != tt.want {
+				t.Errorf("buildPath(%q) = %q, want %q", tt.path, got, tt.want)
+			}
+			if stripped := c.stripBasePath(ctx, got); stripped != cleanKeyPath(tt.path) {
+				t.Errorf("stripBasePath(%q) = %q, want %q", got, stripped, cleanKeyPath(tt.path))
+			}
+		})
+	}
+
+	// Without a context prefix only the base directory prefix applies
+	c := newTestClient(t, "app-data")
+	if got := c.buildPath(context.Background(), "docs/report.pdf"); got != "app-data/docs/report.pdf" {
+		t.Errorf("buildPath without context prefix = %q, want app-data/docs/report.pdf", got)
+	}
+}
+
+func TestContextPrefixValidation(t *testing.T) {
+	c := newTestClient(t, "app-data")
+
+	for _, prefix := range []string{"", "/", "../other-tenant", `tenants\..\..`, "/tenants/42"} {
+		ctx := WithContextPrefix(context.Background(), prefix)
+		if err := c.validatePath(ctx, "docs/report.pdf"); err == nil {
+			t.Errorf("validatePath with context prefix %q = nil, want error", prefix)
+		}
+		if _, err := c.StatObject(ctx, "docs/report.pdf", minio.StatObjectOptions{}); err == nil {
+			t.Errorf("StatObject with context prefix %q = nil error, want error", prefix)
+		}
+	}
+}
+
+func TestContextPrefixOperations(t *testing.T) {
+	c, fake := newFakeS3Client(t, "app-data")
+	ctx := WithContextPrefix(context.Background(), "tenants/42")
+
+	data := []byte("tenant data")
+	if _, err := c.PutObject(ctx, "docs/report.pdf", bytes.NewReader(data), int64(len(data)), minio.PutObjectOptions{}); err != nil {
+		t.Fatalf("PutObject: %v", err)
+	}
+	if fake.object("app-data/tenants/42/docs/report.pdf") == nil {
+		t.Fatal("object was not stored under the context prefix")
+	}
+	fake.put("app-data/tenants/7/docs/other.pdf", []byte("other tenant"), nil)
+
+	var keys []string
+	for object := range c.ListObjects(ctx, "", true) {
+		if object.Err != nil {
+			t.Fatalf("ListObjects: %v", object.Err)
+		}
+		keys = append(keys, object.Key)
+	}
+	if !slices.Equal(keys, []string{"docs/report.pdf"}) {
+		t.Errorf("ListObjects = %q, want [docs/report.pdf]", keys)
+	}
+
+	info, err := c.StatObject(ctx, "docs/report.pdf", minio.StatObjectOptions{})
+	if err != nil || info.Key != "docs/report.pdf" {
+		t.Errorf("StatObject = %q, %v, want docs/report.pdf", info.Key, err)
+	}
+
+	// The other tenant's object is out of scope
+	if _, err := c.StatObject(ctx, "docs/other.pdf", minio.StatObjectOptions{}); err == nil {
+		t.Error("StatObject reached an object outside the context prefix")
+	}
+}
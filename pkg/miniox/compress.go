@@ -0,0 +1,119 @@
+package miniox
+
+import (
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+
+	"github.com/minio/minio-go/v7"
+)
+
+// CompressOptions configures PutObjectCompressed
+type CompressOptions struct {
+	Level       int                    // gzip compression level (default gzip.DefaultCompression)
+	ContentType string                 // Content type of the uncompressed data (detected when empty and auto-detection is enabled)
+	PutOptions  minio.PutObjectOptions // Additional upload options; ContentType and ContentEncoding are overridden
+}
+
+// PutObjectCompressed gzip-compresses data while uploading it and sets Content-Encoding: gzip
+// The content type describes the uncompressed data, so HTTP clients downloading the object decompress it
+// transparently. The compressed size is not known upfront, so the upload is streamed with an unknown size
+// and is not retried
+func (c *Client) PutObjectCompressed(ctx context.Context, objectPath string, reader io.Reader, opts CompressOptions) (uploadInfo minio.UploadInfo, err error) {
+	if err := c.validatePath(ctx, objectPath); err != nil {
+		return minio.UploadInfo{}, err
+	}
+
+	level := opts.Level
+	if level == 0 {
+		level = gzip.DefaultCompression
+	}
+
+	contentType := opts.ContentType
+	if contentType == "" && c.autoDetectContentType {
+		// Detect on the uncompressed data; PutObject would only see gzip bytes
+		contentType, reader, err = detectContentType(objectPath, reader, -1)
+		if err != nil {
+			return minio.UploadInfo{}, fmt.Errorf("failed to detect content type: %w", err)
+		}
+	}
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	gzipWriter, err := gzip.NewWriterLevel(io.Discard, level)
+	if err != nil {
+		return minio.UploadInfo{}, fmt.Errorf("invalid compression level %d: %w", level, err)
+	}
+
+	c.logDebug(ctx, "[MinIO] Putting compressed object",
+		slog.String("bucket", c.bucketName),
+		slog.String("object", objectPath),
+		slog.String("contentType", contentType),
+		slog.Int("level", level))
+
+	pipeReader, pipeWriter := io.Pipe()
+	go func() {
+		gzipWriter.Reset(pipeWriter)
+		_, err := io.Copy(gzipWriter, reader)
+		if closeErr := gzipWriter.Close(); err == nil {
+			err = closeErr
+		}
+		pipeWriter.CloseWithError(err)
+	}()
+
+	putOpts := opts.PutOptions
+	putOpts.ContentType = contentType
+	putOpts.ContentEncoding = "gzip"
+
+	uploadInfo, err = c.PutObject(ctx, objectPath, pipeReader, -1, putOpts)
+
+	// Unblock the compressor if the upload stopped reading early
+	pipeReader.CloseWithError(io.ErrClosedPipe)
+	return uploadInfo, err
+}
+
+// GetObjectDecompressed opens an object and transparently decompresses it if it is stored gzip-encoded
+// Objects without a gzip Content-Encoding are returned unchanged. The caller must close the returned reader
+func (c *Client) GetObjectDecompressed(ctx context.Context, objectPath string) (io.ReadCloser, minio.ObjectInfo, error) {
+	object, info, err := c.OpenObject(ctx, objectPath, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, minio.ObjectInfo{}, err
+	}
+
+	if !isGzipEncoding(info.Metadata.Get("Content-Encoding")) {
+		return object, info, nil
+	}
+
+	gzipReader, err := gzip.NewReader(object)
+	if err != nil {
+		object.Close()
+		return nil, info, fmt.Errorf("failed to decompress %s: %w", objectPath, err)
+	}
+
+	return &decompressingReader{Reader: gzipReader, object: object}, info, nil
+}
+
+// isGzipEncoding reports whether a Content-Encoding value denotes gzip
+func isGzipEncoding(encoding string) bool {
+	encoding = strings.TrimSpace(encoding)
+	return strings.EqualFold(encoding, "gzip") || strings.EqualFold(encoding, "x-gzip")
+}
+
+// decompressingReader reads decompressed data and closes both the gzip reader and the object
+type decompressingReader struct {
+	*gzip.Reader
+	object io.Closer
+}
+
+// Close implements io.Closer
+func (r *decompressingReader) Close() error {
+	gzipErr := r.Reader.Close()
+	if err := r.object.Close(); err != nil {
+		return err
+	}
+	return gzipErr
+}
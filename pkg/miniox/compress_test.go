@@ -0,0 +1,110 @@
+package miniox
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"testing"
+)
+
+// testExport returns a few megabytes of JSON lines, compressible like a real export
+func testExport() []byte {
+	var buf bytes.Buffer
+	for i := range 40000 {
+		fmt.Fprintf(&buf, `{"id":%d,"name":"item-%d","tags":["alpha","beta"],"price":%d.99}`+"\n", i, i, i%100)
+	}
+	return buf.Bytes()
+}
+
+func TestCompressedRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	c, fake := newFakeS3Client(t, "app-data")
+
+	content := testExport()
+	if _, err := c.PutObjectCompressed(ctx, "exports/items.jsonl", bytes.NewReader(content), CompressOptions{
+		Level:       gzip.BestSpeed,
+		ContentType: "application/x-ndjson",
+	}); err != nil {
+		t.Fatalf("PutObjectCompressed: %v", err)
+	}
+
+	stored := fake.object("app-data/exports/items.jsonl")
+	if stored == nil {
+		t.Fatal("object was not stored")
+	}
+	if got := stored.header.Get("Content-Encoding"); got != "gzip" {
+		t.Errorf("Content-Encoding = %q, want gzip", got)
+	}
+	if got := stored.header.Get("Content-Type"); got != "application/x-ndjson" {
+		t.Errorf("Content-Type = %q, want application/x-ndjson", got)
+	}
+	if len(stored.data) >= len(content) {
+		t.Errorf("stored %d bytes for %d bytes of content, want compressed data", len(stored.data), len(content))
+	}
+
+	reader, info, err := c.GetObjectDecompressed(ctx, "exports/items.jsonl")
+	if err != nil {
+		t.Fatalf("GetObjectDecompressed: %v", err)
+	}
+	defer reader.Close()
+
+	got, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	if !bytes.Equal(got, content) {
+		t.Errorf("decompressed %d bytes, want the original %d bytes", len(got), len(content))
+	}
+	if info.Key != "exports/items.jsonl" {
+		t.Errorf("info.Key = %q, want exports/items.jsonl", info.Key)
+	}
+}
+
+func TestGetObjectDecompressedPassesThroughPlainObjects(t *testing.T) {
+	ctx := context.Background()
+	c, fake := newFakeS3Client(t, "")
+
+	// Gzip magic bytes without a gzip Content-Encoding must not be decompressed
+	content := []byte("\x1f\x8b plain bytes that only look compressed")
+	fake.put("plain.bin", content, nil)
+
+	reader, _, err := c.GetObjectDecompressed(ctx, "plain.bin")
+	if err != nil {
+		t.Fatalf("GetObjectDecompressed: %v", err)
+	}
+	defer reader.Close()
+
+	if got, err := io.ReadAll(reader); err != nil || !bytes.Equal(got, content) {
+		t.Errorf("content = %q, %v, want %q", got, err, content)
+	}
+}
+
+func TestPutObjectCompressedInvalidLevel(t *testing.T) {
+	c := newTestClient(t, "")
+
+	if _, err := c.PutObjectCompressed(context.Background(), "a.gz", bytes.NewReader(nil), CompressOptions{Level: 42}); err == nil {
+		t.Error("PutObjectCompressed accepted an invalid compression level")
+	}
+}
+
+func TestIsGzipEncoding(t *testing.T) {
+	tests := []struct {
+		encoding string
+		want     bool
+	}{
+		{"gzip", true},
+		{"GZIP", true},
+		{" x-gzip ", true},
+		{"", false},
+		{"br", false},
+		{"deflate", false},
+	}
+
+	for _, tt := range tests {
+		if got := isGzipEncoding(tt.encoding); got != tt.want {
+			t.Errorf("isGzipEncoding(%q) = %v, want %v", tt.encoding, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package miniox
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+
+	"github.com/minio/minio-go/v7"
+)
+
+// ConcatObjects concatenates source objects in order into a single destination object
+// Unlike ComposeObject there is no minimum source size: the sources are downloaded one after another and
+// streamed into one upload. All sources are checked before uploading, so a missing source fails early, and
+// each source is pinned to the version checked so the declared size always matches the streamed data
+func (c *Client) ConcatObjects(ctx context.Context, destObjectPath string, srcObjectPaths []string, opts minio.PutObjectOptions) (uploadInfo minio.UploadInfo, err error) {
+	if len(srcObjectPaths) == 0 {
+		return minio.UploadInfo{}, fmt.Errorf("at least one source object is required")
+	}
+	if err := c.validatePath(ctx, destObjectPath); err != nil {
+		return minio.UploadInfo{}, err
+	}
+
+	sources := make([]minio.ObjectInfo, len(srcObjectPaths))
+	var totalSize int64
+	for i, srcObjectPath := range srcObjectPaths {
+		info, err := c.StatObject(ctx, srcObjectPath, minio.StatObjectOptions{})
+		if err != nil {
+			if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+				return minio.UploadInfo{}, fmt.Errorf("source object %s does not exist: %w", srcObjectPath, err)
+			}
+			return minio.UploadInfo{}, fmt.Errorf("failed to stat source object %s: %w", srcObjectPath, err)
+		}
+		info.Key = srcObjectPath
+		sources[i] = info
+		totalSize += info.Size
+	}
+
+	c.logDebug(ctx, "[MinIO] Concatenating objects",
+		slog.String("bucket", c.bucketName),
+		slog.String("dest", destObjectPath),
+		slog.Int("sources", len(sources)),
+		slog.Int64("size", totalSize))
+
+	reader := &concatReader{ctx: ctx, client: c, sources: sources}
+	defer reader.Close()
+
+	return c.PutObject(ctx, destObjectPath, reader, totalSize, opts)
+}
+
+// concatReader reads a sequence of objects, opening each one only when the previous one is exhausted
+type concatReader struct {
+	ctx     context.Context
+	client  *Client
+	sources []minio.ObjectInfo
+	current io.ReadCloser
+}
+
+// Read implements io.Reader
+func (r *concatReader) Read(p []byte) (int, error) {
+	for {
+		if r.current == nil {
+			if len(r.sources) == 0 {
+				return 0, io.EOF
+			}
+			if err := r.openNext(); err != nil {
+				return 0, err
+			}
+		}
+
+		n, err := r.current.Read(p)
+		if err == io.EOF {
+			r.current.Close()
+			r.current = nil
+			if n > 0 {
+				return n, nil
+			}
+			continue
+		}
+		return n, err
+	}
+}
+
+// openNext opens the next source pinned to the ETag seen when it was checked
+func (r *concatReader) openNext() error {
+	source := r.sources[0]
+	r.sources = r.sources[1:]
+
+	opts := minio.GetObjectOptions{}
+	if err := opts.SetMatchETag(source.ETag); err != nil {
+		return err
+	}
+
+	object, _, err := r.client.OpenObject(r.ctx, source.Key, opts)
+	if err != nil {
+		if isPreconditionFailed(err) {
+			return fmt.Errorf("source object %s changed during concatenation: %w", source.Key, err)
+		}
+		return fmt.Errorf("failed to open source object %s: %w", source.Key, err)
+	}
+	r.current = object
+	return nil
+}
+
+// Close releases the source currently being read
+func (r *concatReader) Close() error {
+	if r.current == nil {
+		return nil
+	}
+	err := r.current.Close()
+	r.current = nil
+	return err
+}
@@ -0,0 +1,371 @@
+package miniox
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/encrypt"
+)
+
+// ErrPreconditionFailed is returned when a conditional request is rejected because its condition does not hold
+type ErrPreconditionFailed struct {
+	ObjectPath string // Relative object path
+	Condition  string // Condition that failed, e.g. `If-Match: "etag"`
+	Err        error  // Underlying server error
+}
+
+// Error implements the error interface
+func (e *ErrPreconditionFailed) Error() string {
+	return fmt.Sprintf("precondition failed for %s (%s)", e.ObjectPath, e.Condition)
+}
+
+// Unwrap returns the underlying server error
+func (e *ErrPreconditionFailed) Unwrap() error {
+	return e.Err
+}
+
+// PutObjectIfNotExists uploads an object only if no object exists at the path (If-None-Match: *)
+// Returns created=false with a nil error when the object already exists.
+// Conditional writes are honored by AWS S3 and by MinIO server releases from late 2024 onward;
+// older servers ignore the header and overwrite the object. A transient error retried after the
+// first attempt already succeeded on the server is also reported as created=false
+func (c *Client) PutObjectIfNotExists(ctx context.Context, objectPath string, reader io.Reader, objectSize int64, opts minio.PutObjectOptions) (uploadInfo minio.UploadInfo, created bool, err error) {
+	opts.SetMatchETagExcept("*")
+
+	c.logDebug(ctx, "[MinIO] Putting object if not exists",
+		slog.String("bucket", c.bucketName),
+		slog.String("object", objectPath))
+
+	uploadInfo, err = c.PutObject(ctx, objectPath, reader, objectSize, opts)
+	if err != nil {
+		if isPreconditionFailed(err) {
+			return minio.UploadInfo{}, false, nil
+		}
+		return minio.UploadInfo{}, false, err
+	}
+
+	return uploadInfo, true, nil
+}
+
+// PutObjectIfChanged uploads data unless the object already holds identical content
+// The existing object's ETag is compared to the MD5 of data, which only works for single-part uploads without
+// SSE-C or SSE-KMS; when the ETag is not a plain MD5 the data is uploaded. Returns changed=false and the
+// existing object described as an upload when the upload was skipped
+func (c *Client) PutObjectIfChanged(ctx context.Context, objectPath string, data []byte, opts minio.PutObjectOptions) (changed bool, uploadInfo minio.UploadInfo, err error) {
+	ctx, span := c.startOperation(ctx, "PutObjectIfChanged", slog.String("object", objectPath))
+	defer func() { span.End(err) }()
+
+	if err := c.validatePath(ctx, objectPath); err != nil {
+		return false, minio.UploadInfo{}, err
+	}
+
+	fullPath := c.buildPath(ctx, objectPath)
+
+	// Only SSE-C keys are needed to stat the object
+	statSSE := opts.ServerSideEncryption
+	if statSSE != nil && statSSE.Type() != encrypt.SSEC {
+		statSSE = nil
+	}
+
+	// Bypass the stat cache, skipping must be based on the current object
+	existing, err := withRetry(ctx, c, "PutObjectIfChanged", func() (minio.ObjectInfo, error) {
+		return c.minio.StatObject(ctx, c.bucketName, fullPath, minio.StatObjectOptions{ServerSideEncryption: c.readSSE(statSSE)})
+	})
+	if err != nil && minio.ToErrorResponse(err).Code != "NoSuchKey" {
+		return false, minio.UploadInfo{}, err
+	}
+
+	if err == nil && existing.Size == int64(len(data)) && isPlainMD5ETag(existing.ETag) {
+		sum := md5.Sum(data)
+		if strings.EqualFold(strings.Trim(existing.ETag, `"`), hex.EncodeToString(sum[:])) {
+			c.logDebug(ctx, "[MinIO] Object unchanged, skipping upload",
+				slog.String("bucket", c.bucketName),
+				slog.String("object", fullPath),
+				slog.String("etag", existing.ETag))
+
+			existing.Key = c.stripBasePath(ctx, fullPath)
+			return false, uploadInfoFromObjectInfo(c.bucketName, existing), nil
+		}
+	}
+
+	uploadInfo, err = c.PutObject(ctx, objectPath, bytes.NewReader(data), int64(len(data)), opts)
+	if err != nil {
+		return false, minio.UploadInfo{}, err
+	}
+
+	return true, uploadInfo, nil
+}
+
+// isPlainMD5ETag reports whether an ETag is a bare MD5 digest rather than a multipart or encrypted ETag
+func isPlainMD5ETag(etag string) bool {
+	etag = strings.Trim(etag, `"`)
+	if len(etag) != md5.Size*2 {
+		return false
+	}
+	_, err := hex.DecodeString(etag)
+	return err == nil
+}
+
+// PutObjectIfMatch uploads an object only if the current object has the given ETag (If-Match)
+// Returns *ErrPreconditionFailed when the object changed or no longer exists; see PutObjectIfNotExists for server support
+func (c *Client) PutObjectIfMatch(ctx context.Context, objectPath string, etag string, reader io.Reader, objectSize int64, opts minio.PutObjectOptions) (minio.UploadInfo, error) {
+	if etag == "" {
+		return minio.UploadInfo{}, fmt.Errorf("etag is required")
+	}
+
+	opts.SetMatchETag(etag)
+
+	c.logDebug(ctx, "[MinIO] Putting object if match",
+		slog.String("bucket", c.bucketName),
+		slog.String("object", objectPath),
+		slog.String("etag", etag))
+
+	uploadInfo, err := c.PutObject(ctx, objectPath, reader, objectSize, opts)
+	if err != nil {
+		if isPreconditionFailed(err) {
+			return minio.UploadInfo{}, &ErrPreconditionFailed{
+				ObjectPath: objectPath,
+				Condition:  fmt.Sprintf("If-Match: %q", etag),
+				Err:        err,
+			}
+		}
+		return minio.UploadInfo{}, err
+	}
+
+	return uploadInfo, nil
+}
+
+// isPreconditionFailed reports whether the server rejected a request because a condition header did not hold
+func isPreconditionFailed(err error) bool {
+	errResponse := minio.ToErrorResponse(err)
+	return errResponse.Code == "PreconditionFailed" || errResponse.StatusCode == http.StatusPreconditionFailed
+}
+
+// StatObjectIfNoneMatch gets object info unless the object still has the given ETag (If-None-Match)
+// Returns notModified=true when the server answers 304 Not Modified; info then only carries the key and ETag.
+// The stat cache is bypassed so the answer reflects the current object
+func (c *Client) StatObjectIfNoneMatch(ctx context.Context, objectPath string, etag string) (info minio.ObjectInfo, notModified bool, err error) {
+	ctx, span := c.startOperation(ctx, "StatObjectIfNoneMatch", slog.String("object", objectPath))
+	defer func() { span.End(err) }()
+
+	if err := c.validatePath(ctx, objectPath); err != nil {
+		return minio.ObjectInfo{}, false, err
+	}
+	if etag == "" {
+		return minio.ObjectInfo{}, false, fmt.Errorf("etag is required")
+	}
+
+	fullPath := c.buildPath(ctx, objectPath)
+
+	opts := minio.StatObjectOptions{ServerSideEncryption: c.readSSE(nil)}
+	if err := opts.SetMatchETagExcept(strings.Trim(etag, `"`)); err != nil {
+		return minio.ObjectInfo{}, false, err
+	}
+
+	c.logDebug(ctx, "[MinIO] Getting object info if none match",
+		slog.String("bucket", c.bucketName),
+		slog.String("object", fullPath),
+		slog.String("etag", etag))
+
+	info, err = withRetry(ctx, c, "StatObjectIfNoneMatch", func() (minio.ObjectInfo, error) {
+		return c.minio.StatObject(ctx, c.bucketName, fullPath, opts)
+	})
+	if err != nil {
+		if minio.ToErrorResponse(err).StatusCode == http.StatusNotModified {
+			return minio.ObjectInfo{Key: objectPath, ETag: strings.Trim(etag, `"`)}, true, nil
+		}
+		return minio.ObjectInfo{}, false, err
+	}
+
+	info.Key = c.stripBasePath(ctx, info.Key)
+	return info, false, nil
+}
+
+// CopyObjectConditional copies an object within the configured bucket, honoring the source conditions in cond
+// MatchETag, NoMatchETag, MatchModifiedSince, MatchUnmodifiedSince, VersionID and range settings are kept, while
+// the bucket and object are taken from the client. Returns *ErrPreconditionFailed when the copy is rejected
+func (c *Client) CopyObjectConditional(ctx context.Context, destObjectPath string, srcObjectPath string, cond minio.CopySrcOptions, destOpts minio.CopyDestOptions) (uploadInfo minio.UploadInfo, err error) {
+	ctx, span := c.startOperation(ctx, "CopyObjectConditional",
+		slog.String("src", srcObjectPath),
+		slog.String("dest", destObjectPath))
+	defer func() { span.End(err) }()
+
+	if err := c.validatePath(ctx, destObjectPath); err != nil {
+		return minio.UploadInfo{}, err
+	}
+	if err := c.validatePath(ctx, srcObjectPath); err != nil {
+		return minio.UploadInfo{}, err
+	}
+
+	fullDestPath := c.buildPath(ctx, destObjectPath)
+	fullSrcPath := c.buildPath(ctx, srcObjectPath)
+
+	c.logDebug(ctx, "[MinIO] Copying object conditionally",
+		slog.String("bucket", c.bucketName),
+		slog.String("src", fullSrcPath),
+		slog.String("dest", fullDestPath))
+
+	cond.Bucket = c.bucketName
+	cond.Object = fullSrcPath
+	cond.Encryption = c.readSSE(cond.Encryption)
+
+	destOpts.Bucket = c.bucketName
+	destOpts.Object = fullDestPath
+	destOpts.Encryption = c.writeSSE(destOpts.Encryption)
+
+	defer c.invalidateFullPath(fullDestPath)
+	uploadInfo, err = withRetry(ctx, c, "CopyObjectConditional", func() (minio.UploadInfo, error) {
+		return c.minio.CopyObject(ctx, destOpts, cond)
+	})
+	if err != nil {
+		if isPreconditionFailed(err) {
+			return minio.UploadInfo{}, &ErrPreconditionFailed{
+				ObjectPath: srcObjectPath,
+				Condition:  copyConditionString(cond),
+				Err:        err,
+			}
+		}
+		return uploadInfo, err
+	}
+
+	// Strip base path from returned upload info
+	uploadInfo.Key = c.stripBasePath(ctx, uploadInfo.Key)
+	return uploadInfo, nil
+}
+
+// copyConditionString describes the conditions set on copy source options
+func copyConditionString(cond minio.CopySrcOptions) string {
+	var conditions []string
+	if cond.MatchETag != "" {
+		conditions = append(conditions, fmt.Sprintf("If-Match: %q", cond.MatchETag))
+	}
+	if cond.NoMatchETag != "" {
+		conditions = append(conditions, fmt.Sprintf("If-None-Match: %q", cond.NoMatchETag))
+	}
+	if !cond.MatchModifiedSince.IsZero() {
+		conditions = append(conditions, "If-Modified-Since: "+cond.MatchModifiedSince.UTC().Format(http.TimeFormat))
+	}
+	if !cond.MatchUnmodifiedSince.IsZero() {
+		conditions = append(conditions, "If-Unmodified-Since: "+cond.MatchUnmodifiedSince.UTC().Format(http.TimeFormat))
+	}
+	return strings.Join(conditions, ", ")
+}
+
+// defaultUpdateAttempts is the number of read-modify-write cycles UpdateObject tries when none is configured
+const defaultUpdateAttempts = 5
+
+// UpdateOptions configures UpdateObject
+type UpdateOptions struct {
+	MaxAttempts int                    // Read-modify-write cycles before giving up on conflicts (default 5)
+	PutOptions  minio.PutObjectOptions // Options for the upload of the updated content
+}
+
+// UpdateObject atomically updates an object with optimistic concurrency
+// The object is read together with its ETag, passed to update, and written back with If-Match so concurrent
+// changes are never lost. A missing object calls update with nil and is created with If-None-Match.
+// The whole cycle is repeated on conflicts up to opts.MaxAttempts times, after which *ErrPreconditionFailed
+// is returned. update may be called several times and must not have side effects
+func (c *Client) UpdateObject(ctx context.Context, objectPath string, update func(current []byte) ([]byte, error), opts UpdateOptions) (err error) {
+	ctx, span := c.startOperation(ctx, "UpdateObject", slog.String("object", objectPath))
+	defer func() { span.End(err) }()
+
+	if err := c.validatePath(ctx, objectPath); err != nil {
+		return err
+	}
+
+	maxAttempts := opts.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = defaultUpdateAttempts
+	}
+
+	var conflict error
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		if attempt > 1 {
+			backoff := c.retryBackoff(attempt - 1)
+
+			c.logDebug(ctx, "[MinIO] Retrying object update after conflict",
+				slog.String("bucket", c.bucketName),
+				slog.String("object", objectPath),
+				slog.Int("attempt", attempt),
+				slog.Duration("backoff", backoff))
+
+			select {
+			case <-time.After(backoff):
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+
+		current, etag, err := c.readObjectWithETag(ctx, objectPath)
+		if err != nil {
+			var preconditionErr *ErrPreconditionFailed
+			if !errors.As(err, &preconditionErr) {
+				return err
+			}
+			conflict = err
+			continue
+		}
+
+		next, err := update(current)
+		if err != nil {
+			return err
+		}
+
+		if etag == "" {
+			_, created, err := c.PutObjectIfNotExists(ctx, objectPath, bytes.NewReader(next), int64(len(next)), opts.PutOptions)
+			if err != nil {
+				return err
+			}
+			if created {
+				return nil
+			}
+			conflict = &ErrPreconditionFailed{ObjectPath: objectPath, Condition: "If-None-Match: *"}
+			continue
+		}
+
+		_, err = c.PutObjectIfMatch(ctx, objectPath, etag, bytes.NewReader(next), int64(len(next)), opts.PutOptions)
+		if err == nil {
+			return nil
+		}
+		var preconditionErr *ErrPreconditionFailed
+		if !errors.As(err, &preconditionErr) {
+			return err
+		}
+		conflict = err
+	}
+
+	return fmt.Errorf("object %s was modified concurrently %d times: %w", objectPath, maxAttempts, conflict)
+}
+
+// readObjectWithETag reads the whole object and the ETag of the version read
+// A missing object returns nil content and an empty ETag. Reads after the first request are pinned to the ETag,
+// so an object replaced while it is being read fails with *ErrPreconditionFailed
+func (c *Client) readObjectWithETag(ctx context.Context, objectPath string) ([]byte, string, error) {
+	object, info, err := c.OpenObject(ctx, objectPath, minio.GetObjectOptions{})
+	if err != nil {
+		if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+			return nil, "", nil
+		}
+		return nil, "", err
+	}
+	defer object.Close()
+
+	data, err := io.ReadAll(object)
+	if err != nil {
+		if isPreconditionFailed(err) {
+			return nil, "", &ErrPreconditionFailed{ObjectPath: objectPath, Condition: fmt.Sprintf("If-Match: %q", info.ETag), Err: err}
+		}
+		return nil, "", fmt.Errorf("failed to read object %s: %w", objectPath, err)
+	}
+	return data, info.ETag, nil
+}
@@ -0,0 +1,206 @@
+package miniox
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+)
+
+// newFakeUpdateClient returns a fake-backed client with short conflict backoffs
+func newFakeUpdateClient(t *testing.T) (*Client, *fakeS3) {
+	t.Helper()
+
+	c, fake := newFakeS3Client(t, "app-data")
+	c.retry = normalizeRetryConfig(RetryConfig{InitialBackoff: time.Millisecond, MaxBackoff: 5 * time.Millisecond})
+	return c, fake
+}
+
+// increment is an update function treating the object as a decimal counter; a missing object counts as zero
+func increment(current []byte) ([]byte, error) {
+	if current == nil {
+		return []byte("1"), nil
+	}
+	n, err := strconv.Atoi(string(current))
+	if err != nil {
+		return nil, err
+	}
+	return []byte(strconv.Itoa(n + 1)), nil
+}
+
+func TestUpdateObjectCreatesMissingObject(t *testing.T) {
+	ctx := context.Background()
+	c, fake := newFakeUpdateClient(t)
+
+	var calls int
+	err := c.UpdateObject(ctx, "counter", func(current []byte) ([]byte, error) {
+		calls++
+		if current != nil {
+			t.Errorf("update called with %q for a missing object, want nil", current)
+		}
+		return increment(current)
+	}, UpdateOptions{})
+	if err != nil {
+		t.Fatalf("UpdateObject: %v", err)
+	}
+
+	if calls != 1 {
+		t.Errorf("update called %d times, want 1", calls)
+	}
+	if object := fake.object("app-data/counter"); object == nil || string(object.data) != "1" {
+		t.Errorf("stored object = %v, want 1", object)
+	}
+}
+
+func TestUpdateObjectRetriesOnConflict(t *testing.T) {
+	ctx := context.Background()
+	c, fake := newFakeUpdateClient(t)
+	fake.put("app-data/counter", []byte("10"), nil)
+
+	// The first cycle loses the race against a write made between its read and its write
+	var calls int
+	err := c.UpdateObject(ctx, "counter", func(current []byte) ([]byte, error) {
+		calls++
+		if calls == 1 {
+			fake.put("app-data/counter", []byte("20"), nil)
+		}
+		return increment(current)
+	}, UpdateOptions{})
+	if err != nil {
+		t.Fatalf("UpdateObject: %v", err)
+	}
+
+	if calls != 2 {
+		t.Errorf("update called %d times, want 2", calls)
+	}
+	if got := string(fake.object("app-data/counter").data); got != "21" {
+		t.Errorf("counter = %s, want 21 (the concurrent write must not be lost)", got)
+	}
+}
+
+func TestUpdateObjectCreateConflict(t *testing.T) {
+	ctx := context.Background()
+	c, fake := newFakeUpdateClient(t)
+
+	// Another writer creates the object between the read and the If-None-Match write
+	var calls int
+	err := c.UpdateObject(ctx, "counter", func(current []byte) ([]byte, error) {
+		calls++
+		if calls == 1 {
+			fake.put("app-data/counter", []byte("5"), nil)
+		}
+		return increment(current)
+	}, UpdateOptions{})
+	if err != nil {
+		t.Fatalf("UpdateObject: %v", err)
+	}
+
+	if got := string(fake.object("app-data/counter").data); got != "6" {
+		t.Errorf("counter = %s, want 6", got)
+	}
+}
+
+func TestUpdateObjectGivesUp(t *testing.T) {
+	ctx := context.Background()
+	c, fake := newFakeUpdateClient(t)
+	fake.put("app-data/counter", []byte("0"), nil)
+
+	var calls int
+	err := c.UpdateObject(ctx, "counter", func(current []byte) ([]byte, error) {
+		calls++
+		fake.put("app-data/counter", []byte(strconv.Itoa(100+calls)), nil)
+		return increment(current)
+	}, UpdateOptions{MaxAttempts: 3})
+
+	var preconditionErr *ErrPreconditionFailed
+	if !errors.As(err, &preconditionErr) {
+		t.Fatalf("UpdateObject error = %v, want *ErrPreconditionFailed", err)
+	}
+	if calls != 3 {
+		t.Errorf("update called %d times, want 3", calls)
+	}
+}
+
+func TestUpdateObjectUpdateError(t *testing.T) {
+	ctx := context.Background()
+	c, fake := newFakeUpdateClient(t)
+	fake.put("app-data/counter", []byte("1"), nil)
+
+	errAbort := errors.New("abort")
+	err := c.UpdateObject(ctx, "counter", func([]byte) ([]byte, error) {
+		return nil, errAbort
+	}, UpdateOptions{})
+	if !errors.Is(err, errAbort) {
+		t.Errorf("UpdateObject error = %v, want the update error", err)
+	}
+	if got := fake.requestCount("PUT", "app-data/counter"); got != 0 {
+		t.Errorf("PUT requests = %d, want 0", got)
+	}
+}
+
+func TestUpdateObjectConcurrentUpdaters(t *testing.T) {
+	ctx := context.Background()
+	c, fake := newFakeUpdateClient(t)
+
+	const updaters, updates = 2, 20
+
+	var wg sync.WaitGroup
+	errs := make(chan error, updaters*updates)
+	for range updaters {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range updates {
+				errs <- c.UpdateObject(ctx, "counter", increment, UpdateOptions{MaxAttempts: 100})
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("UpdateObject: %v", err)
+		}
+	}
+	if got := string(fake.object("app-data/counter").data); got != strconv.Itoa(updaters*updates) {
+		t.Errorf("counter = %s, want %d (updates were lost)", got, updaters*updates)
+	}
+}
+
+func TestPutObjectIfChanged(t *testing.T) {
+	ctx := context.Background()
+	c, fake := newFakeS3Client(t, "app-data")
+
+	data := []byte(`{"version":1}`)
+	changed, info, err := c.PutObjectIfChanged(ctx, "docs//state.json", data, minio.PutObjectOptions{})
+	if err != nil || !changed {
+		t.Fatalf("first PutObjectIfChanged = %v, %v, want changed", changed, err)
+	}
+	if info.Key != "docs/state.json" {
+		t.Errorf("uploaded key = %q, want docs/state.json", info.Key)
+	}
+
+	// Identical content is skipped and reported with the same normalized key
+	for _, objectPath := range []string{"docs/state.json", "docs//state.json", `docs\state.json`} {
+		changed, info, err = c.PutObjectIfChanged(ctx, objectPath, data, minio.PutObjectOptions{})
+		if err != nil || changed {
+			t.Errorf("PutObjectIfChanged(%q) = %v, %v, want unchanged", objectPath, changed, err)
+		}
+		if info.Key != "docs/state.json" {
+			t.Errorf("PutObjectIfChanged(%q) key = %q, want docs/state.json", objectPath, info.Key)
+		}
+	}
+	if got := fake.requestCount("PUT", "app-data/docs/state.json"); got != 1 {
+		t.Errorf("PUT requests = %d, want 1", got)
+	}
+
+	changed, _, err = c.PutObjectIfChanged(ctx, "docs/state.json", []byte(`{"version":2}`), minio.PutObjectOptions{})
+	if err != nil || !changed {
+		t.Errorf("PutObjectIfChanged with new content = %v, %v, want changed", changed, err)
+	}
+}
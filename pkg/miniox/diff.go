@@ -0,0 +1,152 @@
+package miniox
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+
+	"github.com/minio/minio-go/v7"
+)
+
+// DiffOptions configures DiffPrefixesWithOpts
+type DiffOptions struct {
+	Glob              string // Only compare keys matching this pattern (see ObjectFilter.Glob); all when empty
+	SizeOnlyForNonMD5 bool   // Compare only sizes when either ETag is not a plain MD5 (multipart or encrypted uploads)
+}
+
+// DiffResult reports the differences between two prefixes
+// Keys are relative to their prefix and sorted
+type DiffResult struct {
+	OnlyInA   []string // Keys present only under prefix A
+	OnlyInB   []string // Keys present only under prefix B
+	Different []string // Keys present on both sides with a different size or ETag
+	Same      int      // Number of keys identical on both sides
+}
+
+// DiffPrefixes compares the objects under prefixA on this client with the objects under prefixB on other
+// other may be the same client. See DiffPrefixesWithOpts
+func (c *Client) DiffPrefixes(ctx context.Context, other *Client, prefixA, prefixB string) (DiffResult, error) {
+	return c.DiffPrefixesWithOpts(ctx, other, prefixA, prefixB, DiffOptions{})
+}
+
+// DiffPrefixesWithOpts compares two prefixes with the given options
+// Both listings are streamed in key order and merged, so memory only grows with the number of differences.
+// Objects are compared by size and ETag; multipart ETags depend on the part size, so set opts.SizeOnlyForNonMD5
+// when comparing data uploaded with different tools or part sizes
+func (c *Client) DiffPrefixesWithOpts(ctx context.Context, other *Client, prefixA, prefixB string, opts DiffOptions) (result DiffResult, err error) {
+	ctx, span := c.startSpan(ctx, "DiffPrefixes",
+		slog.String("prefixA", prefixA),
+		slog.String("bucketB", other.bucketName),
+		slog.String("prefixB", prefixB))
+	defer func() { span.End(err) }()
+
+	if err := c.validatePath(ctx, prefixA); err != nil {
+		return DiffResult{}, err
+	}
+	if err := other.validatePath(ctx, prefixB); err != nil {
+		return DiffResult{}, err
+	}
+	if opts.Glob != "" {
+		if err := validateGlob(opts.Glob); err != nil {
+			return DiffResult{}, err
+		}
+	}
+
+	c.logDebug(ctx, "[MinIO] Comparing prefixes",
+		slog.String("bucket", c.bucketName),
+		slog.String("prefixA", prefixA),
+		slog.String("bucketB", other.bucketName),
+		slog.String("prefixB", prefixB),
+		slog.String("glob", opts.Glob))
+
+	// Both listings stop when the comparison returns
+	listCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	a := c.diffListing(listCtx, prefixA, opts.Glob)
+	b := other.diffListing(listCtx, prefixB, opts.Glob)
+
+	objectA, okA, err := a.next()
+	if err != nil {
+		return DiffResult{}, err
+	}
+	objectB, okB, err := b.next()
+	if err != nil {
+		return DiffResult{}, err
+	}
+
+	for okA || okB {
+		switch {
+		case !okB || (okA && objectA.Key < objectB.Key):
+			result.OnlyInA = append(result.OnlyInA, objectA.Key)
+			objectA, okA, err = a.next()
+		case !okA || objectB.Key < objectA.Key:
+			result.OnlyInB = append(result.OnlyInB, objectB.Key)
+			objectB, okB, err = b.next()
+		default:
+			if sameObject(objectA, objectB, opts.SizeOnlyForNonMD5) {
+				result.Same++
+			} else {
+				result.Different = append(result.Different, objectA.Key)
+			}
+			if objectA, okA, err = a.next(); err == nil {
+				objectB, okB, err = b.next()
+			}
+		}
+		if err != nil {
+			return DiffResult{}, err
+		}
+	}
+
+	return result, nil
+}
+
+// sameObject reports whether two listed objects hold the same content as far as the listing can tell
+func sameObject(a, b minio.ObjectInfo, sizeOnlyForNonMD5 bool) bool {
+	if a.Size != b.Size {
+		return false
+	}
+	if sizeOnlyForNonMD5 && (!isPlainMD5ETag(a.ETag) || !isPlainMD5ETag(b.ETag)) {
+		return true
+	}
+	return strings.Trim(a.ETag, `"`) == strings.Trim(b.ETag, `"`)
+}
+
+// diffListing is a recursive listing of a folder prefix yielding keys relative to the prefix
+type diffListing struct {
+	objects    <-chan minio.ObjectInfo
+	fullPrefix string
+	glob       []string
+}
+
+// diffListing starts a listing for DiffPrefixes
+func (c *Client) diffListing(ctx context.Context, prefix string, glob string) *diffListing {
+	fullPrefix := c.buildFolderPath(ctx, prefix)
+	listing := &diffListing{
+		objects: c.minio.ListObjects(ctx, c.bucketName, minio.ListObjectsOptions{
+			Prefix:    fullPrefix,
+			Recursive: true,
+		}),
+		fullPrefix: fullPrefix,
+	}
+	if glob != "" {
+		listing.glob = strings.Split(glob, "/")
+	}
+	return listing
+}
+
+// next returns the next object matching the glob; ok is false once the listing is exhausted
+func (l *diffListing) next() (objectInfo minio.ObjectInfo, ok bool, err error) {
+	for objectInfo := range l.objects {
+		if objectInfo.Err != nil {
+			return minio.ObjectInfo{}, false, objectInfo.Err
+		}
+
+		objectInfo.Key = strings.TrimPrefix(objectInfo.Key, l.fullPrefix)
+		if l.glob != nil && !matchGlob(l.glob, strings.Split(objectInfo.Key, "/")) {
+			continue
+		}
+		return objectInfo, true, nil
+	}
+	return minio.ObjectInfo{}, false, nil
+}
@@ -0,0 +1,41 @@
+package miniox
+
+import (
+	"github.com/minio/minio-go/v7/pkg/encrypt"
+)
+
+// SSES3 returns server-side encryption options using keys managed by the server (SSE-S3)
+func SSES3() encrypt.ServerSide {
+	return encrypt.NewSSE()
+}
+
+// SSEKMS returns server-side encryption options using a KMS key (SSE-KMS)
+// kmsContext is an optional encryption context and may be nil
+func SSEKMS(keyID string, kmsContext any) (encrypt.ServerSide, error) {
+	return encrypt.NewSSEKMS(keyID, kmsContext)
+}
+
+// SSEC returns server-side encryption options using a customer provided 32-byte key (SSE-C)
+func SSEC(key []byte) (encrypt.ServerSide, error) {
+	return encrypt.NewSSEC(key)
+}
+
+// writeSSE returns the encryption to apply on writes, falling back to the configured default
+func (c *Client) writeSSE(sse encrypt.ServerSide) encrypt.ServerSide {
+	if sse != nil {
+		return sse
+	}
+	return c.defaultSSE
+}
+
+// readSSE returns the encryption to send on reads
+// Only SSE-C requires the key on reads, so the default is applied only when it is SSE-C
+func (c *Client) readSSE(sse encrypt.ServerSide) encrypt.ServerSide {
+	if sse != nil {
+		return sse
+	}
+	if c.defaultSSE != nil && c.defaultSSE.Type() == encrypt.SSEC {
+		return c.defaultSSE
+	}
+	return nil
+}
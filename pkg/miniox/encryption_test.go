@@ -0,0 +1,110 @@
+package miniox
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/encrypt"
+)
+
+// testSSECKey is a 32-byte customer key for SSE-C tests
+var testSSECKey = []byte("0123456789abcdef0123456789abcdef")
+
+func TestSSEConstructors(t *testing.T) {
+	if sse := SSES3(); sse.Type() != encrypt.S3 {
+		t.Errorf("SSES3().Type() = %v, want %v", sse.Type(), encrypt.S3)
+	}
+
+	sse, err := SSEKMS("my-key", map[string]string{"app": "test"})
+	if err != nil || sse.Type() != encrypt.KMS {
+		t.Errorf("SSEKMS = %v, %v, want KMS encryption", sse, err)
+	}
+
+	sse, err = SSEC(testSSECKey)
+	if err != nil || sse.Type() != encrypt.SSEC {
+		t.Errorf("SSEC = %v, %v, want SSE-C encryption", sse, err)
+	}
+	if _, err := SSEC([]byte("too short")); err == nil {
+		t.Error("SSEC accepted a key that is not 32 bytes")
+	}
+}
+
+func TestDefaultSSESelection(t *testing.T) {
+	ssec, err := SSEC(testSSECKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sses3 := SSES3()
+
+	tests := []struct {
+		name       string
+		defaultSSE encrypt.ServerSide
+		explicit   encrypt.ServerSide
+		wantWrite  encrypt.ServerSide
+		wantRead   encrypt.ServerSide
+	}{
+		{"no default", nil, nil, nil, nil},
+		{"SSE-S3 default is not sent on reads", sses3, nil, sses3, nil},
+		{"SSE-C default is sent on reads", ssec, nil, ssec, ssec},
+		{"explicit overrides default", ssec, sses3, sses3, sses3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t, "")
+			c.defaultSSE = tt.defaultSSE
+
+			if got := c.writeSSE(tt.explicit); got != tt.wantWrite {
+				t.Errorf("writeSSE = %v, want %v", got, tt.wantWrite)
+			}
+			if got := c.readSSE(tt.explicit); got != tt.wantRead {
+				t.Errorf("readSSE = %v, want %v", got, tt.wantRead)
+			}
+		})
+	}
+}
+
+func TestDefaultSSECRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	c, fake := newFakeS3Client(t, "app-data")
+
+	var err error
+	c.defaultSSE, err = SSEC(testSSECKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	content := []byte("confidential")
+	if _, err := c.PutObject(ctx, "secret.txt", bytes.NewReader(content), int64(len(content)), minio.PutObjectOptions{}); err != nil {
+		t.Fatalf("PutObject: %v", err)
+	}
+	stored := fake.object("app-data/secret.txt")
+	if stored == nil || stored.header.Get("X-Amz-Server-Side-Encryption-Customer-Algorithm") != "AES256" {
+		t.Fatal("object was not written with the default SSE-C key")
+	}
+
+	// Reads attach the key automatically
+	if _, err := c.StatObject(ctx, "secret.txt", minio.StatObjectOptions{}); err != nil {
+		t.Fatalf("StatObject: %v", err)
+	}
+	object, err := c.GetObject(ctx, "secret.txt", minio.GetObjectOptions{})
+	if err != nil {
+		t.Fatalf("GetObject: %v", err)
+	}
+	defer object.Close()
+	if got, err := io.ReadAll(object); err != nil || !bytes.Equal(got, content) {
+		t.Errorf("GetObject content = %q, %v, want %q", got, err, content)
+	}
+
+	// A different key is rejected, so the default key really is what makes reads succeed
+	otherKey, err := SSEC(bytes.Repeat([]byte("k"), 32))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.StatObject(ctx, "secret.txt", minio.StatObjectOptions{ServerSideEncryption: otherKey}); err == nil {
+		t.Error("StatObject succeeded with the wrong SSE-C key")
+	}
+}
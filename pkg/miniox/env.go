@@ -0,0 +1,53 @@
+package miniox
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+)
+
+// defaultEnvPrefix is the environment variable prefix used by NewFromEnv when none is given
+const defaultEnvPrefix = "MINIO"
+
+// NewFromEnv creates a client configured from environment variables
+// The variables are <PREFIX>_ENDPOINT, <PREFIX>_ACCESS_KEY, <PREFIX>_SECRET_KEY, <PREFIX>_USE_SSL,
+// <PREFIX>_BUCKET, <PREFIX>_BASE_DIR_PREFIX and <PREFIX>_PUBLIC_URL, with the prefix defaulting to "MINIO".
+// See ConfigFromEnv for how they are read
+func NewFromEnv(prefix string) (*Client, error) {
+	config, err := ConfigFromEnv(prefix)
+	if err != nil {
+		return nil, err
+	}
+	return New(config)
+}
+
+// ConfigFromEnv builds a Config from environment variables, so options without a variable can be set before New
+// <PREFIX>_USE_SSL accepts the values understood by strconv.ParseBool and is false when unset; any other value is
+// an error. Missing required variables are reported by Config.Validate
+func ConfigFromEnv(prefix string) (*Config, error) {
+	if prefix == "" {
+		prefix = defaultEnvPrefix
+	}
+	env := func(name string) string {
+		return os.Getenv(prefix + "_" + name)
+	}
+
+	config := &Config{
+		Endpoint:      env("ENDPOINT"),
+		AccessKey:     env("ACCESS_KEY"),
+		SecretKey:     env("SECRET_KEY"),
+		BucketName:    env("BUCKET"),
+		BaseDirPrefix: env("BASE_DIR_PREFIX"),
+		PublicURL:     env("PUBLIC_URL"),
+	}
+
+	if useSSL := env("USE_SSL"); useSSL != "" {
+		parsed, err := strconv.ParseBool(useSSL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s_USE_SSL %q: must be true or false", prefix, useSSL)
+		}
+		config.UseSSL = parsed
+	}
+
+	return config, nil
+}
@@ -0,0 +1,146 @@
+package miniox
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+)
+
+// ObjectFilter selects objects returned by ListObjectsFiltered; zero-valued fields do not filter
+type ObjectFilter struct {
+	Glob           string                      // Pattern matched against the key relative to the prefix; path.Match syntax plus "**" for any number of folders
+	ModifiedAfter  time.Time                   // Only objects modified strictly after this time
+	ModifiedBefore time.Time                   // Only objects modified strictly before this time
+	MinSize        int64                       // Minimum size in bytes
+	MaxSize        int64                       // Maximum size in bytes (no limit when zero)
+	Match          func(minio.ObjectInfo) bool // Custom predicate, called last with the key relative to the base directory prefix
+}
+
+// ListObjectsFiltered recursively lists the objects under a folder prefix that match the filter
+// Filtering happens while the listing is streamed, nothing is buffered. Returned keys are relative to the base
+// directory prefix like ListObjects; an invalid glob is reported as the only element before any listing starts.
+// Cancel ctx to stop early
+func (c *Client) ListObjectsFiltered(ctx context.Context, prefix string, filter ObjectFilter) <-chan minio.ObjectInfo {
+	ctx, span := c.startSpan(ctx, "ListObjectsFiltered",
+		slog.String("prefix", prefix),
+		slog.String("glob", filter.Glob))
+
+	errorCh := func(err error) <-chan minio.ObjectInfo {
+		span.End(err)
+		ch := make(chan minio.ObjectInfo, 1)
+		ch <- minio.ObjectInfo{Err: err}
+		close(ch)
+		return ch
+	}
+
+	if err := c.validatePath(ctx, prefix); err != nil {
+		return errorCh(err)
+	}
+	if filter.Glob != "" {
+		if err := validateGlob(filter.Glob); err != nil {
+			return errorCh(err)
+		}
+	}
+
+	fullPrefix := c.buildFolderPath(ctx, prefix)
+	c.logDebug(ctx, "[MinIO] Listing filtered objects",
+		slog.String("bucket", c.bucketName),
+		slog.String("prefix", fullPrefix),
+		slog.String("glob", filter.Glob))
+
+	opts := minio.ListObjectsOptions{
+		Prefix:    fullPrefix,
+		Recursive: true,
+	}
+	objectCh := c.minio.ListObjects(ctx, c.bucketName, opts)
+
+	filteredCh := make(chan minio.ObjectInfo)
+	go func() {
+		var listErr error
+		defer func() { span.End(listErr) }()
+		defer close(filteredCh)
+		for objectInfo := range objectCh {
+			if objectInfo.Err != nil {
+				listErr = objectInfo.Err
+			} else {
+				if !filter.matches(strings.TrimPrefix(objectInfo.Key, fullPrefix), objectInfo) {
+					continue
+				}
+				objectInfo.Key = c.stripBasePath(ctx, objectInfo.Key)
+				if filter.Match != nil && !filter.Match(objectInfo) {
+					continue
+				}
+			}
+
+			select {
+			case filteredCh <- objectInfo:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return filteredCh
+}
+
+// matches applies the built-in criteria to an object; relativeKey is the key relative to the listed prefix
+func (f ObjectFilter) matches(relativeKey string, objectInfo minio.ObjectInfo) bool {
+	if !f.ModifiedAfter.IsZero() && !objectInfo.LastModified.After(f.ModifiedAfter) {
+		return false
+	}
+	if !f.ModifiedBefore.IsZero() && !objectInfo.LastModified.Before(f.ModifiedBefore) {
+		return false
+	}
+	if objectInfo.Size < f.MinSize || (f.MaxSize > 0 && objectInfo.Size > f.MaxSize) {
+		return false
+	}
+	return f.Glob == "" || matchGlob(strings.Split(f.Glob, "/"), strings.Split(relativeKey, "/"))
+}
+
+// validateGlob checks every segment of a glob pattern for syntax errors
+func validateGlob(pattern string) error {
+	for _, segment := range strings.Split(pattern, "/") {
+		if segment == "**" {
+			continue
+		}
+		if _, err := path.Match(segment, ""); err != nil {
+			return fmt.Errorf("invalid glob pattern %q: %w", pattern, err)
+		}
+	}
+	return nil
+}
+
+// matchGlob matches key segments against pattern segments, where a "**" segment matches any number of segments
+func matchGlob(pattern, segments []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			// Collapse consecutive "**" and try every possible split point
+			for len(pattern) > 0 && pattern[0] == "**" {
+				pattern = pattern[1:]
+			}
+			if len(pattern) == 0 {
+				return true
+			}
+			for i := range segments {
+				if matchGlob(pattern, segments[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+
+		if len(segments) == 0 {
+			return false
+		}
+		if ok, _ := path.Match(pattern[0], segments[0]); !ok {
+			return false
+		}
+		pattern, segments = pattern[1:], segments[1:]
+	}
+	return len(segments) == 0
+}
@@ -2,27 +2,41 @@ package miniox
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
+	"maps"
+	"path"
+	"slices"
 	"strings"
 
-	"github.com/aeternitas-infinita/rmlog"
 	"github.com/minio/minio-go/v7"
 )
 
+// folderMarkerName is the name of the empty object that marks a folder's existence
+const folderMarkerName = ".empty"
+
+// isFolderMarker reports whether the object key is a folder marker rather than regular content
+func isFolderMarker(key string) bool {
+	return strings.HasSuffix(key, "/") || key == folderMarkerName || strings.HasSuffix(key, "/"+folderMarkerName)
+}
+
 // FolderExists checks if a folder exists with automatic path prefix handling
-func (c *Client) FolderExists(ctx context.Context, folderPath string) (bool, error) {
-	if err := c.ValidatePath(folderPath); err != nil {
+func (c *Client) FolderExists(ctx context.Context, folderPath string) (exists bool, err error) {
+	ctx, span := c.startOperation(ctx, "FolderExists", slog.String("folder", folderPath))
+	defer func() { span.End(err) }()
+
+	if err := c.validatePath(ctx, folderPath); err != nil {
 		return false, err
 	}
 
-	fullPath := c.buildPath(folderPath)
-	filePath := fullPath + "/.empty"
+	fullPath := c.buildFolderPath(ctx, folderPath)
+	filePath := fullPath + folderMarkerName
 
-	rmlog.DebugCtxMin(ctx, "[MinIO] Checking folder existence",
+	c.logDebug(ctx, "[MinIO] Checking folder existence",
 		slog.String("bucket", c.bucketName),
 		slog.String("folder", fullPath))
 
-	_, err := c.minio.StatObject(ctx, c.bucketName, filePath, minio.StatObjectOptions{})
+	_, err = c.minio.StatObject(ctx, c.bucketName, filePath, minio.StatObjectOptions{})
 	if err == nil {
 		return true, nil
 	}
@@ -33,8 +47,11 @@ func (c *Client) FolderExists(ctx context.Context, folderPath string) (bool, err
 }
 
 // CreateFolder creates an empty folder with automatic path prefix handling
-func (c *Client) CreateFolder(ctx context.Context, folderPath string) error {
-	if err := c.ValidatePath(folderPath); err != nil {
+func (c *Client) CreateFolder(ctx context.Context, folderPath string) (err error) {
+	ctx, span := c.startOperation(ctx, "CreateFolder", slog.String("folder", folderPath))
+	defer func() { span.End(err) }()
+
+	if err := c.validatePath(ctx, folderPath); err != nil {
 		return err
 	}
 
@@ -45,10 +62,10 @@ func (c *Client) CreateFolder(ctx context.Context, folderPath string) error {
 		return err
 	}
 
-	fullPath := c.buildPath(folderPath)
-	filePath := fullPath + "/.empty"
+	fullPath := c.buildFolderPath(ctx, folderPath)
+	filePath := fullPath + folderMarkerName
 
-	rmlog.DebugCtxMin(ctx, "[MinIO] Creating folder",
+	c.logDebug(ctx, "[MinIO] Creating folder",
 		slog.String("bucket", c.bucketName),
 		slog.String("folder", fullPath))
 
@@ -56,18 +73,133 @@ func (c *Client) CreateFolder(ctx context.Context, folderPath string) error {
 	return err
 }
 
+// EnsureFolders creates folder markers for a folder and all its parents ("a/b/c" creates a, a/b and a/b/c)
+// Levels that already exist are skipped; creating a marker is idempotent, so concurrent callers are safe
+func (c *Client) EnsureFolders(ctx context.Context, folderPath string) (err error) {
+	ctx, span := c.startOperation(ctx, "EnsureFolders", slog.String("folder", folderPath))
+	defer func() { span.End(err) }()
+
+	if err := c.validatePath(ctx, folderPath); err != nil {
+		return err
+	}
+
+	cleanPath := strings.Trim(toSlash(folderPath), "/")
+	if cleanPath == "" {
+		return fmt.Errorf("folder path is required")
+	}
+
+	level := ""
+	for _, segment := range strings.Split(cleanPath, "/") {
+		if segment == "" {
+			continue // Duplicate slashes
+		}
+		level = path.Join(level, segment)
+		if err := c.CreateFolder(ctx, level); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// IsFolderEmpty reports whether a folder contains no objects other than folder markers
+// The folder is listed recursively so subfolders holding content count, while empty subfolders do not;
+// listing stops at the first object found
+func (c *Client) IsFolderEmpty(ctx context.Context, folderPath string) (empty bool, err error) {
+	ctx, span := c.startOperation(ctx, "IsFolderEmpty", slog.String("folder", folderPath))
+	defer func() { span.End(err) }()
+
+	if err := c.validatePath(ctx, folderPath); err != nil {
+		return false, err
+	}
+
+	fullPath := c.buildFolderPath(ctx, folderPath)
+
+	c.logDebug(ctx, "[MinIO] Checking whether folder is empty",
+		slog.String("bucket", c.bucketName),
+		slog.String("folder", fullPath))
+
+	// Stop the listing at the first object found
+	listCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	opts := minio.ListObjectsOptions{
+		Prefix:    fullPath,
+		Recursive: true,
+	}
+	for objectInfo := range c.minio.ListObjects(listCtx, c.bucketName, opts) {
+		if objectInfo.Err != nil {
+			return false, objectInfo.Err
+		}
+		if !isFolderMarker(objectInfo.Key) {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// EmptyFolder removes everything inside a folder but keeps the folder itself, returning the number of deleted objects
+// The folder marker is preserved (or created if missing) so the folder stays visible; nested folders are removed
+func (c *Client) EmptyFolder(ctx context.Context, folderPath string) (deleted int64, err error) {
+	if err := c.validatePath(ctx, folderPath); err != nil {
+		return 0, err
+	}
+
+	cleanPath := strings.Trim(toSlash(folderPath), "/")
+	if cleanPath == "" {
+		return 0, fmt.Errorf("refusing to empty the bucket root")
+	}
+	markerPath := c.stripBasePath(ctx, c.buildFolderPath(ctx, cleanPath)+folderMarkerName)
+
+	result, err := c.RemoveObjectsByPrefix(ctx, cleanPath+"/", func(objectInfo minio.ObjectInfo) bool {
+		return objectInfo.Key != markerPath
+	}, RemoveOptions{})
+	if err != nil {
+		return int64(len(result.Deleted)), err
+	}
+
+	if err := c.CreateFolder(ctx, cleanPath); err != nil {
+		return int64(len(result.Deleted)), err
+	}
+
+	return int64(len(result.Deleted)), nil
+}
+
 // RemoveFolder removes all objects with a given prefix (folder) with automatic path prefix handling
+// Removal continues past individual failures; an error summarizing them is returned at the end
 func (c *Client) RemoveFolder(ctx context.Context, folderPath string) error {
-	if err := c.ValidatePath(folderPath); err != nil {
+	_, failures, err := c.RemoveFolderWithResult(ctx, folderPath)
+	if err != nil {
 		return err
 	}
 
-	fullPath := c.buildPath(folderPath)
-	if !strings.HasSuffix(fullPath, "/") {
-		fullPath += "/"
+	if len(failures) > 0 {
+		first := slices.Min(slices.Collect(maps.Keys(failures)))
+		return fmt.Errorf("failed to remove %d objects from folder %s (e.g. %s): %w", len(failures), folderPath, first, failures[first])
 	}
 
-	rmlog.DebugCtxMin(ctx, "[MinIO] Removing folder",
+	return nil
+}
+
+// RemoveFolderWithResult removes all objects in a folder and reports the number of deleted objects
+// The listing is streamed and deleted in batches, so large folders are never held in memory. Individual removal
+// failures are collected by relative key instead of aborting the operation; err is only set when the folder could
+// not be listed, in which case the objects removed before the listing failed are still reported
+func (c *Client) RemoveFolderWithResult(ctx context.Context, folderPath string) (deleted int, failures map[string]error, err error) {
+	ctx, span := c.startOperation(ctx, "RemoveFolder", slog.String("folder", folderPath))
+	defer func() { span.End(err) }()
+
+	if err := c.validatePath(ctx, folderPath); err != nil {
+		return 0, nil, err
+	}
+
+	fullPath := c.buildFolderPath(ctx, folderPath)
+	if fullPath == "" {
+		return 0, nil, fmt.Errorf("refusing to remove the bucket root")
+	}
+
+	c.logDebug(ctx, "[MinIO] Removing folder",
 		slog.String("bucket", c.bucketName),
 		slog.String("folder", fullPath))
 
@@ -76,33 +208,50 @@ func (c *Client) RemoveFolder(ctx context.Context, folderPath string) error {
 		Recursive: true,
 	}
 
-	objectCh := c.minio.ListObjects(ctx, c.bucketName, opts)
-	errorCh := c.minio.RemoveObjects(ctx, c.bucketName, objectCh, minio.RemoveObjectsOptions{})
+	var result RemoveResult
+	batch := make([]minio.ObjectInfo, 0, removeBatchSize)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		var err error
+		result, err = c.removeObjectBatches(ctx, batch, RemoveOptions{ContinueOnError: true}, result)
+		batch = batch[:0]
+		return err
+	}
 
-	// Check for errors during removal
-	for removeErr := range errorCh {
-		if removeErr.Err != nil {
-			return removeErr.Err
+	for objectInfo := range c.minio.ListObjects(ctx, c.bucketName, opts) {
+		if objectInfo.Err != nil {
+			return len(result.Deleted), result.Errors, objectInfo.Err
+		}
+		batch = append(batch, objectInfo)
+		if len(batch) == removeBatchSize {
+			if err := flush(); err != nil {
+				return len(result.Deleted), result.Errors, err
+			}
 		}
 	}
 
-	return nil
+	if err := flush(); err != nil {
+		return len(result.Deleted), result.Errors, err
+	}
+
+	return len(result.Deleted), result.Errors, nil
 }
 
 // ListFolders lists folders (common prefixes) in the given path
-func (c *Client) ListFolders(ctx context.Context, prefix string) ([]string, error) {
-	if prefix != "" {
-		if err := c.ValidatePath(prefix); err != nil {
-			return nil, err
-		}
-	}
+// Names are relative to the prefix, and folders that only contain a folder marker are included
+func (c *Client) ListFolders(ctx context.Context, prefix string) (folders []string, err error) {
+	ctx, span := c.startOperation(ctx, "ListFolders", slog.String("prefix", prefix))
+	defer func() { span.End(err) }()
 
-	fullPrefix := c.buildPath(prefix)
-	if fullPrefix != "" && !strings.HasSuffix(fullPrefix, "/") {
-		fullPrefix += "/"
+	if err := c.validatePath(ctx, prefix); err != nil {
+		return nil, err
 	}
 
-	rmlog.DebugCtxMin(ctx, "[MinIO] Listing folders",
+	fullPrefix := c.buildFolderPath(ctx, prefix)
+
+	c.logDebug(ctx, "[MinIO] Listing folders",
 		slog.String("bucket", c.bucketName),
 		slog.String("prefix", fullPrefix))
 
@@ -112,7 +261,6 @@ func (c *Client) ListFolders(ctx context.Context, prefix string) ([]string, erro
 	}
 
 	objectCh := c.minio.ListObjects(ctx, c.bucketName, opts)
-	var folders []string
 	seenFolders := make(map[string]bool)
 
 	for objectInfo := range objectCh {
@@ -120,24 +268,85 @@ func (c *Client) ListFolders(ctx context.Context, prefix string) ([]string, erro
 			return nil, objectInfo.Err
 		}
 
-		// Extract folder name from object key
-		relativePath := c.stripBasePath(objectInfo.Key)
-		if prefix != "" {
-			// Remove the prefix from the relative path
-			prefixToRemove := strings.Trim(prefix, "/") + "/"
-			relativePath = strings.TrimPrefix(relativePath, prefixToRemove)
+		folderName, ok := listedFolderName(objectInfo.Key, fullPrefix)
+		if !ok || seenFolders[folderName] {
+			continue
 		}
+		folders = append(folders, folderName)
+		seenFolders[folderName] = true
+	}
 
-		// Get the first directory component
-		parts := strings.Split(relativePath, "/")
-		if len(parts) > 1 && parts[0] != "" {
-			folderName := parts[0]
-			if !seenFolders[folderName] {
-				folders = append(folders, folderName)
-				seenFolders[folderName] = true
-			}
+	return folders, nil
+}
+
+// listedFolderName returns the name of the direct subfolder of fullPrefix reported by a non-recursive listing key
+// Non-recursive listings report subfolders as common prefixes ending in "/", including folders that only hold a
+// marker; plain objects at this level are not folders
+func listedFolderName(key string, fullPrefix string) (string, bool) {
+	if !strings.HasSuffix(key, "/") || !strings.HasPrefix(key, fullPrefix) {
+		return "", false
+	}
+
+	folderName := strings.TrimSuffix(strings.TrimPrefix(key, fullPrefix), "/")
+	if folderName == "" || strings.Contains(folderName, "/") {
+		return "", false
+	}
+	return folderName, true
+}
+
+// PruneFolderMarkers removes folder markers that are redundant because their folder contains other objects
+// Markers of empty folders are kept so those folders continue to exist
+func (c *Client) PruneFolderMarkers(ctx context.Context, prefix string) (removed int, err error) {
+	ctx, span := c.startOperation(ctx, "PruneFolderMarkers", slog.String("prefix", prefix))
+	defer func() { span.End(err) }()
+
+	if err := c.validatePath(ctx, prefix); err != nil {
+		return 0, err
+	}
+
+	fullPrefix := c.buildFolderPath(ctx, prefix)
+
+	c.logDebug(ctx, "[MinIO] Pruning folder markers",
+		slog.String("bucket", c.bucketName),
+		slog.String("prefix", fullPrefix))
+
+	opts := minio.ListObjectsOptions{
+		Prefix:    fullPrefix,
+		Recursive: true,
+	}
+
+	markers := make(map[string]minio.ObjectInfo)
+	nonEmptyFolders := make(map[string]bool)
+
+	for objectInfo := range c.minio.ListObjects(ctx, c.bucketName, opts) {
+		if objectInfo.Err != nil {
+			return 0, objectInfo.Err
+		}
+
+		key := objectInfo.Key
+		if path.Base(key) == folderMarkerName {
+			markers[path.Dir(key)] = objectInfo
+			// A nested marker still makes every ancestor folder non-empty
+			key = path.Dir(key)
+		}
+
+		// Every ancestor folder of this object has content
+		for dir := path.Dir(key); dir != "." && dir != "/"; dir = path.Dir(dir) {
+			nonEmptyFolders[dir] = true
 		}
 	}
 
-	return folders, nil
+	var redundant []minio.ObjectInfo
+	for dir, marker := range markers {
+		if nonEmptyFolders[dir] {
+			redundant = append(redundant, marker)
+		}
+	}
+
+	if len(redundant) == 0 {
+		return 0, nil
+	}
+
+	result, err := c.removeObjectBatches(ctx, redundant, RemoveOptions{}, RemoveResult{})
+	return len(result.Deleted), err
 }
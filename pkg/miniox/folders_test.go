@@ -0,0 +1,227 @@
+package miniox
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"testing"
+)
+
+func TestBuildFolderPath(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name          string
+		baseDirPrefix string
+		folderPath    string
+		want          string
+	}{
+		{"plain", "", "images", "images/"},
+		{"trailing slash", "", "images/", "images/"},
+		{"leading slash", "", "/images", "images/"},
+		{"both slashes", "", "/images/", "images/"},
+		{"nested", "", "images/2024/", "images/2024/"},
+		{"root", "", "", ""},
+		{"root slash", "", "/", ""},
+		{"with prefix", "app-data", "images", "app-data/images/"},
+		{"with prefix and trailing slash", "app-data", "images/", "app-data/images/"},
+		{"with prefix and leading slash", "app-data", "/images", "app-data/images/"},
+		{"prefix root", "app-data", "", "app-data/"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t, tt.baseDirPrefix)
+			if got := c.buildFolderPath(ctx, tt.folderPath); got != tt.want {
+				t.Errorf("buildFolderPath(%q) = %q, want %q", tt.folderPath, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFolderSlashTolerance(t *testing.T) {
+	ctx := context.Background()
+	c, fake := newFakeS3Client(t, "app-data")
+
+	if err := c.CreateFolder(ctx, "images/"); err != nil {
+		t.Fatalf("CreateFolder: %v", err)
+	}
+	if fake.object("app-data/images/.empty") == nil {
+		t.Fatal("folder marker was not created at app-data/images/.empty")
+	}
+
+	for _, folderPath := range []string{"images", "images/", "images//"} {
+		exists, err := c.FolderExists(ctx, folderPath)
+		if err != nil || !exists {
+			t.Errorf("FolderExists(%q) = %v, %v, want true, nil", folderPath, exists, err)
+		}
+	}
+
+	// Operations reject a leading slash as an absolute path rather than silently dropping it
+	if _, err := c.FolderExists(ctx, "/images"); err == nil {
+		t.Error("FolderExists accepted an absolute path")
+	}
+
+	// Creating the folder again with another spelling does not write a second marker
+	if err := c.CreateFolder(ctx, "images"); err != nil {
+		t.Fatalf("CreateFolder: %v", err)
+	}
+	if got := fake.requestCount("PUT", "app-data/images/.empty"); got != 1 {
+		t.Errorf("marker PUT requests = %d, want 1", got)
+	}
+
+	fake.put("app-data/images/logo.png", []byte("png"), nil)
+	folders, err := c.ListFolders(ctx, "")
+	if err != nil || !slices.Equal(folders, []string{"images"}) {
+		t.Errorf("ListFolders = %q, %v, want [images]", folders, err)
+	}
+
+	if err := c.RemoveFolder(ctx, "images/"); err != nil {
+		t.Fatalf("RemoveFolder: %v", err)
+	}
+	if fake.object("app-data/images/.empty") != nil || fake.object("app-data/images/logo.png") != nil {
+		t.Error("RemoveFolder left objects behind")
+	}
+	if exists, err := c.FolderExists(ctx, "images"); err != nil || exists {
+		t.Errorf("FolderExists after RemoveFolder = %v, %v, want false, nil", exists, err)
+	}
+}
+
+func TestEnsureFolders(t *testing.T) {
+	ctx := context.Background()
+	c, fake := newFakeS3Client(t, "app-data")
+
+	if err := c.EnsureFolders(ctx, "x/y/z"); err != nil {
+		t.Fatalf("EnsureFolders: %v", err)
+	}
+
+	for _, folderPath := range []string{"x", "x/y", "x/y/z"} {
+		exists, err := c.FolderExists(ctx, folderPath)
+		if err != nil || !exists {
+			t.Errorf("FolderExists(%q) = %v, %v, want true, nil", folderPath, exists, err)
+		}
+		if fake.object("app-data/"+folderPath+"/.empty") == nil {
+			t.Errorf("marker for %s was not created", folderPath)
+		}
+	}
+
+	// Existing levels are skipped, so a second call and an overlapping tree write no duplicate markers
+	if err := c.EnsureFolders(ctx, "x/y/z/"); err != nil {
+		t.Fatalf("EnsureFolders: %v", err)
+	}
+	if err := c.EnsureFolders(ctx, "x//y/w"); err != nil {
+		t.Fatalf("EnsureFolders: %v", err)
+	}
+	for _, folderPath := range []string{"x", "x/y", "x/y/z", "x/y/w"} {
+		if got := fake.requestCount("PUT", "app-data/"+folderPath+"/.empty"); got != 1 {
+			t.Errorf("marker PUT requests for %s = %d, want 1", folderPath, got)
+		}
+	}
+
+	for _, folderPath := range []string{"", "/", "../x"} {
+		if err := c.EnsureFolders(ctx, folderPath); err == nil {
+			t.Errorf("EnsureFolders(%q) = nil, want error", folderPath)
+		}
+	}
+}
+
+func TestEnsureFoldersConcurrent(t *testing.T) {
+	ctx := context.Background()
+	c, _ := newFakeS3Client(t, "")
+
+	folderPaths := []string{"a/b/c", "a/b/d", "a/e", "a/b/c/f"}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, len(folderPaths))
+	for _, folderPath := range folderPaths {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- c.EnsureFolders(ctx, folderPath)
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("EnsureFolders: %v", err)
+		}
+	}
+	for _, folderPath := range []string{"a", "a/b", "a/b/c", "a/b/d", "a/e", "a/b/c/f"} {
+		if exists, err := c.FolderExists(ctx, folderPath); err != nil || !exists {
+			t.Errorf("FolderExists(%q) = %v, %v, want true, nil", folderPath, exists, err)
+		}
+	}
+}
+
+func TestListedFolderName(t *testing.T) {
+	tests := []struct {
+		name       string
+		key        string
+		fullPrefix string
+		want       string
+		wantOK     bool
+	}{
+		{"root folder", "docs/", "", "docs", true},
+		{"nested folder", "docs/sub/", "docs/", "sub", true},
+		{"with base prefix", "app-data/docs/sub/", "app-data/docs/", "sub", true},
+		{"deeply nested prefix", "app-data/a/b/c/d/", "app-data/a/b/c/", "d", true},
+		{"plain object", "docs/report.pdf", "docs/", "", false},
+		{"folder marker", "docs/.empty", "docs/", "", false},
+		{"the prefix itself", "docs/", "docs/", "", false},
+		{"outside the prefix", "other/sub/", "docs/", "", false},
+		{"sibling sharing a name prefix", "docs-old/sub/", "docs", "", false},
+		{"not a direct child", "docs/sub/deeper/", "docs/", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := listedFolderName(tt.key, tt.fullPrefix)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("listedFolderName(%q, %q) = %q, %v, want %q, %v", tt.key, tt.fullPrefix, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestListFolders(t *testing.T) {
+	ctx := context.Background()
+
+	for _, baseDirPrefix := range []string{"", "app-data"} {
+		t.Run("prefix "+baseDirPrefix, func(t *testing.T) {
+			c, fake := newFakeS3Client(t, baseDirPrefix)
+			full := func(key string) string {
+				return c.buildPath(ctx, key)
+			}
+
+			fake.put(full("docs/readme.txt"), []byte("top level object"), nil)
+			fake.put(full("docs/sub/report.pdf"), []byte("pdf"), nil)
+			fake.put(full("docs/marker-only/.empty"), nil, nil)
+			fake.put(full("docs/nested/a/b/deep.txt"), []byte("deep"), nil)
+			fake.put(full("docs-old/archived/file.txt"), []byte("sibling"), nil)
+
+			tests := []struct {
+				prefix string
+				want   []string
+			}{
+				{"docs", []string{"marker-only", "nested", "sub"}},
+				{"docs/", []string{"marker-only", "nested", "sub"}},
+				{"docs/nested", []string{"a"}},
+				{"docs/nested/a/", []string{"b"}},
+				{"docs/marker-only", nil},
+				{"", []string{"docs-old", "docs"}}, // Listing order is bytewise and "-" sorts before "/"
+			}
+
+			for _, tt := range tests {
+				folders, err := c.ListFolders(ctx, tt.prefix)
+				if err != nil {
+					t.Fatalf("ListFolders(%q): %v", tt.prefix, err)
+				}
+				if !slices.Equal(folders, tt.want) {
+					t.Errorf("ListFolders(%q) = %q, want %q", tt.prefix, folders, tt.want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,213 @@
+package miniox
+
+import (
+	"context"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"hash/crc32"
+	"log/slog"
+	"maps"
+	"net/http"
+	"strings"
+
+	"github.com/minio/minio-go/v7"
+)
+
+// HashAlgo selects the hash function used to digest object content
+type HashAlgo string
+
+const (
+	HashSHA256 HashAlgo = "sha256" // SHA-256
+	HashMD5    HashAlgo = "md5"    // MD5
+	HashCRC32C HashAlgo = "crc32c" // CRC-32 with the Castagnoli polynomial
+)
+
+// newHash returns a new hash for the algorithm, defaulting to SHA-256
+func newHash(algo HashAlgo) (hash.Hash, error) {
+	switch algo {
+	case HashSHA256, "":
+		return sha256.New(), nil
+	case HashMD5:
+		return md5.New(), nil
+	case HashCRC32C:
+		return crc32.New(crc32.MakeTable(crc32.Castagnoli)), nil
+	default:
+		return nil, fmt.Errorf("unsupported hash algorithm %q", algo)
+	}
+}
+
+// hashObject streams an object through the hash and returns the hex digest and the number of bytes hashed
+// The download is pinned to etag when it is set
+func (c *Client) hashObject(ctx context.Context, objectPath string, algo HashAlgo, etag string) (string, int64, error) {
+	hasher, err := newHash(algo)
+	if err != nil {
+		return "", 0, err
+	}
+
+	opts := minio.GetObjectOptions{}
+	if etag != "" {
+		if err := opts.SetMatchETag(etag); err != nil {
+			return "", 0, err
+		}
+	}
+
+	n, err := c.WriteObjectTo(ctx, objectPath, hasher, opts)
+	if err != nil {
+		return "", 0, err
+	}
+	return hex.EncodeToString(hasher.Sum(nil)), n, nil
+}
+
+// hashMetadataPrefix prefixes the user metadata key caching an object digest, e.g. "Miniox-Sha256"
+const hashMetadataPrefix = "Miniox-"
+
+// HashOptions configures HashObjectWithOpts
+type HashOptions struct {
+	Algorithm       HashAlgo // Hash algorithm (default SHA-256)
+	StoreInMetadata bool     // Reuse a digest cached in user metadata, and cache a computed one there via a self-copy
+}
+
+// hashMetadataKey returns the user metadata key caching a digest for the algorithm
+func hashMetadataKey(algo HashAlgo) string {
+	if algo == "" {
+		algo = HashSHA256
+	}
+	return http.CanonicalHeaderKey(hashMetadataPrefix + string(algo))
+}
+
+// HashObject streams an object through the hash function and returns the hex digest
+// See HashObjectWithOpts
+func (c *Client) HashObject(ctx context.Context, objectPath string, algo HashAlgo) (string, error) {
+	return c.HashObjectWithOpts(ctx, objectPath, HashOptions{Algorithm: algo})
+}
+
+// HashObjectWithOpts returns the hex digest of an object with the given options
+// The download is pinned to the stat'd version, so hashing fails if the object is replaced mid-download.
+// With opts.StoreInMetadata a digest cached in user metadata is returned without downloading; otherwise the
+// computed digest is cached by copying the object onto itself, keeping its content headers, storage class and
+// tags. Any overwrite of the object drops the cached digest. Caching is best effort: a failed self-copy is logged
+// and the digest is still returned
+func (c *Client) HashObjectWithOpts(ctx context.Context, objectPath string, opts HashOptions) (digest string, err error) {
+	ctx, span := c.startSpan(ctx, "HashObject",
+		slog.String("object", objectPath),
+		slog.String("algorithm", string(opts.Algorithm)))
+	defer func() { span.End(err) }()
+
+	if err := c.validatePath(ctx, objectPath); err != nil {
+		return "", err
+	}
+	if _, err := newHash(opts.Algorithm); err != nil {
+		return "", err
+	}
+
+	info, err := c.statUncached(ctx, "HashObject", objectPath)
+	if err != nil {
+		return "", err
+	}
+
+	metadataKey := hashMetadataKey(opts.Algorithm)
+	if cached := info.UserMetadata[metadataKey]; opts.StoreInMetadata && cached != "" {
+		return cached, nil
+	}
+
+	c.logDebug(ctx, "[MinIO] Hashing object",
+		slog.String("bucket", c.bucketName),
+		slog.String("object", c.buildPath(ctx, objectPath)),
+		slog.String("algorithm", string(opts.Algorithm)),
+		slog.Int64("size", info.Size))
+
+	digest, _, err = c.hashObject(ctx, objectPath, opts.Algorithm, info.ETag)
+	if err != nil {
+		return "", err
+	}
+
+	if opts.StoreInMetadata {
+		userMetadata := maps.Clone(info.UserMetadata)
+		if userMetadata == nil {
+			userMetadata = make(map[string]string, 2)
+		}
+		userMetadata[metadataKey] = digest
+		if info.StorageClass != "" {
+			// Replacing the metadata would otherwise reset the storage class
+			userMetadata[storageClassHeader] = info.StorageClass
+		}
+
+		if _, copyErr := c.selfCopy(ctx, objectPath, info, userMetadata, ""); copyErr != nil {
+			c.logInfo("[MinIO] Failed to store object hash in metadata",
+				slog.String("bucket", c.bucketName),
+				slog.String("object", c.buildPath(ctx, objectPath)),
+				slog.String("error", copyErr.Error()))
+		}
+	}
+
+	return digest, nil
+}
+
+// CompareObjects reports whether two objects have identical content
+// Objects of different sizes differ, and objects with equal ETags or plain MD5 ETags are decided by their ETags.
+// Otherwise (multipart or encrypted uploads) the SHA-256 digests cached by HashObjectWithOpts are compared when
+// both objects have one, and the objects are streamed through SHA-256 when not
+func (c *Client) CompareObjects(ctx context.Context, pathA, pathB string) (equal bool, err error) {
+	ctx, span := c.startSpan(ctx, "CompareObjects",
+		slog.String("objectA", pathA),
+		slog.String("objectB", pathB))
+	defer func() { span.End(err) }()
+
+	if err := c.validatePath(ctx, pathA); err != nil {
+		return false, err
+	}
+	if err := c.validatePath(ctx, pathB); err != nil {
+		return false, err
+	}
+
+	infoA, err := c.statUncached(ctx, "CompareObjects", pathA)
+	if err != nil {
+		return false, err
+	}
+	infoB, err := c.statUncached(ctx, "CompareObjects", pathB)
+	if err != nil {
+		return false, err
+	}
+
+	etagA, etagB := strings.Trim(infoA.ETag, `"`), strings.Trim(infoB.ETag, `"`)
+	switch {
+	case infoA.Size != infoB.Size:
+		return false, nil
+	case etagA == etagB:
+		return true, nil
+	case isPlainMD5ETag(etagA) && isPlainMD5ETag(etagB):
+		return false, nil
+	}
+
+	metadataKey := hashMetadataKey(HashSHA256)
+	if cachedA, cachedB := infoA.UserMetadata[metadataKey], infoB.UserMetadata[metadataKey]; cachedA != "" && cachedB != "" {
+		return strings.EqualFold(cachedA, cachedB), nil
+	}
+
+	c.logDebug(ctx, "[MinIO] Comparing objects by content hash",
+		slog.String("bucket", c.bucketName),
+		slog.String("objectA", c.buildPath(ctx, pathA)),
+		slog.String("objectB", c.buildPath(ctx, pathB)),
+		slog.Int64("size", infoA.Size))
+
+	digestA, _, err := c.hashObject(ctx, pathA, HashSHA256, infoA.ETag)
+	if err != nil {
+		return false, err
+	}
+	digestB, _, err := c.hashObject(ctx, pathB, HashSHA256, infoB.ETag)
+	if err != nil {
+		return false, err
+	}
+	return digestA == digestB, nil
+}
+
+// statUncached stats an object bypassing the stat cache
+func (c *Client) statUncached(ctx context.Context, operation string, objectPath string) (minio.ObjectInfo, error) {
+	fullPath := c.buildPath(ctx, objectPath)
+	return withRetry(ctx, c, operation, func() (minio.ObjectInfo, error) {
+		return c.minio.StatObject(ctx, c.bucketName, fullPath, minio.StatObjectOptions{ServerSideEncryption: c.readSSE(nil)})
+	})
+}
@@ -0,0 +1,162 @@
+package miniox
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/lifecycle"
+)
+
+// GetBucketLifecycle gets the lifecycle configuration of the configured bucket
+// Rule prefixes are returned as stored (including the base directory prefix); see ListLifecycleRules for relative prefixes
+func (c *Client) GetBucketLifecycle(ctx context.Context) (config *lifecycle.Configuration, err error) {
+	ctx, span := c.startOperation(ctx, "GetBucketLifecycle")
+	defer func() { span.End(err) }()
+
+	c.logDebug(ctx, "[MinIO] Getting bucket lifecycle",
+		slog.String("bucket", c.bucketName))
+
+	return c.minio.GetBucketLifecycle(ctx, c.bucketName)
+}
+
+// SetBucketLifecycle sets the lifecycle configuration of the configured bucket
+// An empty configuration removes the bucket lifecycle
+func (c *Client) SetBucketLifecycle(ctx context.Context, config *lifecycle.Configuration) (err error) {
+	ctx, span := c.startOperation(ctx, "SetBucketLifecycle")
+	defer func() { span.End(err) }()
+
+	c.logDebug(ctx, "[MinIO] Setting bucket lifecycle",
+		slog.String("bucket", c.bucketName))
+
+	return c.minio.SetBucketLifecycle(ctx, c.bucketName, config)
+}
+
+// AddExpirationRule adds or replaces a lifecycle rule expiring objects under a relative prefix after the given days
+// The prefix is resolved with the base directory prefix, so "tmp" targets "<base>/tmp/"
+func (c *Client) AddExpirationRule(ctx context.Context, relativePrefix string, days int, ruleID string) error {
+	if ruleID == "" {
+		return fmt.Errorf("rule ID is required")
+	}
+	if days <= 0 {
+		return fmt.Errorf("expiration days must be positive")
+	}
+	if err := c.validatePath(ctx, relativePrefix); err != nil {
+		return err
+	}
+
+	config, err := c.getLifecycleOrEmpty(ctx)
+	if err != nil {
+		return err
+	}
+
+	rule := lifecycle.Rule{
+		ID:     ruleID,
+		Status: "Enabled",
+		RuleFilter: lifecycle.Filter{
+			Prefix: c.buildFolderPath(ctx, relativePrefix),
+		},
+		Expiration: lifecycle.Expiration{
+			Days: lifecycle.ExpirationDays(days),
+		},
+	}
+
+	c.logDebug(ctx, "[MinIO] Adding lifecycle expiration rule",
+		slog.String("bucket", c.bucketName),
+		slog.String("ruleID", ruleID),
+		slog.String("prefix", rule.RuleFilter.Prefix),
+		slog.Int("days", days))
+
+	replaced := false
+	for i := range config.Rules {
+		if config.Rules[i].ID == ruleID {
+			config.Rules[i] = rule
+			replaced = true
+		}
+	}
+	if !replaced {
+		config.Rules = append(config.Rules, rule)
+	}
+
+	return c.SetBucketLifecycle(ctx, config)
+}
+
+// RemoveLifecycleRule removes a lifecycle rule by ID
+// Returns an error if no rule with the ID exists
+func (c *Client) RemoveLifecycleRule(ctx context.Context, ruleID string) error {
+	config, err := c.getLifecycleOrEmpty(ctx)
+	if err != nil {
+		return err
+	}
+
+	c.logDebug(ctx, "[MinIO] Removing lifecycle rule",
+		slog.String("bucket", c.bucketName),
+		slog.String("ruleID", ruleID))
+
+	rules := config.Rules[:0]
+	for _, rule := range config.Rules {
+		if rule.ID != ruleID {
+			rules = append(rules, rule)
+		}
+	}
+	if len(rules) == len(config.Rules) {
+		return fmt.Errorf("lifecycle rule %s not found", ruleID)
+	}
+	config.Rules = rules
+
+	return c.SetBucketLifecycle(ctx, config)
+}
+
+// ListLifecycleRules lists lifecycle rules that apply within the base directory prefix
+// Rule prefixes are stripped back to relative form; rules outside the base directory prefix are omitted
+func (c *Client) ListLifecycleRules(ctx context.Context) ([]lifecycle.Rule, error) {
+	config, err := c.getLifecycleOrEmpty(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	basePrefix := c.buildFolderPath(ctx, "")
+
+	var rules []lifecycle.Rule
+	for _, rule := range config.Rules {
+		if !strings.HasPrefix(lifecycleRulePrefix(rule), basePrefix) {
+			continue
+		}
+
+		rule.Prefix = c.stripBasePath(ctx, rule.Prefix)
+		rule.RuleFilter.Prefix = c.stripBasePath(ctx, rule.RuleFilter.Prefix)
+		rule.RuleFilter.And.Prefix = c.stripBasePath(ctx, rule.RuleFilter.And.Prefix)
+		rules = append(rules, rule)
+	}
+
+	return rules, nil
+}
+
+// getLifecycleOrEmpty gets the bucket lifecycle, returning an empty configuration if none is set
+func (c *Client) getLifecycleOrEmpty(ctx context.Context) (*lifecycle.Configuration, error) {
+	config, err := c.GetBucketLifecycle(ctx)
+	if err != nil {
+		if minio.ToErrorResponse(err).Code == "NoSuchLifecycleConfiguration" {
+			return lifecycle.NewConfiguration(), nil
+		}
+		return nil, err
+	}
+	if config == nil {
+		return lifecycle.NewConfiguration(), nil
+	}
+	return config, nil
+}
+
+// lifecycleRulePrefix returns the effective prefix of a lifecycle rule
+func lifecycleRulePrefix(rule lifecycle.Rule) string {
+	switch {
+	case rule.RuleFilter.Prefix != "":
+		return rule.RuleFilter.Prefix
+	case rule.RuleFilter.And.Prefix != "":
+		return rule.RuleFilter.And.Prefix
+	default:
+		return rule.Prefix
+	}
+}
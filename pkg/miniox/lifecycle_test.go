@@ -0,0 +1,103 @@
+package miniox
+
+import (
+	"context"
+	"testing"
+
+	"github.com/minio/minio-go/v7/pkg/lifecycle"
+)
+
+func TestLifecycleRulesRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	c, fake := newFakeS3Client(t, "app-data")
+
+	rules, err := c.ListLifecycleRules(ctx)
+	if err != nil || len(rules) != 0 {
+		t.Fatalf("ListLifecycleRules without configuration = %v, %v, want none", rules, err)
+	}
+
+	// A rule managed by someone else outside the base directory prefix
+	config := lifecycle.NewConfiguration()
+	config.Rules = []lifecycle.Rule{{
+		ID:         "foreign",
+		Status:     "Enabled",
+		RuleFilter: lifecycle.Filter{Prefix: "other/"},
+		Expiration: lifecycle.Expiration{Days: 1},
+	}}
+	if err := c.SetBucketLifecycle(ctx, config); err != nil {
+		t.Fatalf("SetBucketLifecycle: %v", err)
+	}
+
+	if err := c.AddExpirationRule(ctx, "tmp", 7, "tmp-expiry"); err != nil {
+		t.Fatalf("AddExpirationRule: %v", err)
+	}
+
+	config, err = c.GetBucketLifecycle(ctx)
+	if err != nil {
+		t.Fatalf("GetBucketLifecycle: %v", err)
+	}
+	if len(config.Rules) != 2 {
+		t.Fatalf("stored rules = %+v, want the foreign rule and tmp-expiry", config.Rules)
+	}
+	if rule := config.Rules[1]; rule.ID != "tmp-expiry" || rule.RuleFilter.Prefix != "app-data/tmp/" || rule.Expiration.Days != 7 {
+		t.Errorf("stored rule = %+v, want tmp-expiry on app-data/tmp/ after 7 days", rule)
+	}
+
+	// Adding a rule with an existing ID replaces it
+	if err := c.AddExpirationRule(ctx, "tmp/", 30, "tmp-expiry"); err != nil {
+		t.Fatalf("AddExpirationRule: %v", err)
+	}
+
+	rules, err = c.ListLifecycleRules(ctx)
+	if err != nil {
+		t.Fatalf("ListLifecycleRules: %v", err)
+	}
+	if len(rules) != 1 || rules[0].ID != "tmp-expiry" || rules[0].RuleFilter.Prefix != "tmp/" || rules[0].Expiration.Days != 30 {
+		t.Errorf("ListLifecycleRules = %+v, want only tmp-expiry on tmp/ after 30 days", rules)
+	}
+
+	if err := c.RemoveLifecycleRule(ctx, "tmp-expiry"); err != nil {
+		t.Fatalf("RemoveLifecycleRule: %v", err)
+	}
+	if err := c.RemoveLifecycleRule(ctx, "tmp-expiry"); err == nil {
+		t.Error("RemoveLifecycleRule of a missing rule returned no error")
+	}
+
+	config, err = c.GetBucketLifecycle(ctx)
+	if err != nil || len(config.Rules) != 1 || config.Rules[0].ID != "foreign" {
+		t.Errorf("rules after removal = %+v, %v, want only the foreign rule", config, err)
+	}
+
+	// Removing the last rule removes the configuration
+	if err := c.RemoveLifecycleRule(ctx, "foreign"); err != nil {
+		t.Fatalf("RemoveLifecycleRule: %v", err)
+	}
+	if fake.lifecycle != nil {
+		t.Errorf("lifecycle configuration left after removing every rule: %s", fake.lifecycle)
+	}
+}
+
+func TestAddExpirationRuleValidation(t *testing.T) {
+	ctx := context.Background()
+	c := newTestClient(t, "app-data")
+
+	tests := []struct {
+		name   string
+		prefix string
+		days   int
+		ruleID string
+	}{
+		{"missing rule ID", "tmp", 7, ""},
+		{"zero days", "tmp", 0, "tmp-expiry"},
+		{"negative days", "tmp", -1, "tmp-expiry"},
+		{"path traversal", "../tmp", 7, "tmp-expiry"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := c.AddExpirationRule(ctx, tt.prefix, tt.days, tt.ruleID); err == nil {
+				t.Error("AddExpirationRule accepted an invalid rule")
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package miniox
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// UploadLimits restricts what can be uploaded; zero values disable the corresponding check
+type UploadLimits struct {
+	MaxObjectSize       int64    // Maximum object size in bytes
+	AllowedContentTypes []string // Allowed content types; entries ending in "/" (e.g. "image/") match by prefix
+}
+
+// ErrObjectTooLarge is returned when an upload exceeds the maximum object size
+type ErrObjectTooLarge struct {
+	ObjectPath string // Relative object path
+	Size       int64  // Declared size, or the number of bytes read when the size was unknown
+	MaxSize    int64  // Configured limit
+}
+
+// Error implements the error interface
+func (e *ErrObjectTooLarge) Error() string {
+	return fmt.Sprintf("object %s is too large: %d bytes exceeds the limit of %d", e.ObjectPath, e.Size, e.MaxSize)
+}
+
+// ErrContentTypeNotAllowed is returned when an upload has a content type outside the allowed list
+type ErrContentTypeNotAllowed struct {
+	ObjectPath  string // Relative object path
+	ContentType string // Rejected content type
+}
+
+// Error implements the error interface
+func (e *ErrContentTypeNotAllowed) Error() string {
+	return fmt.Sprintf("content type %q is not allowed for %s", e.ContentType, e.ObjectPath)
+}
+
+// checkContentType validates a content type against the allowed list
+// An empty content type is checked as application/octet-stream, which is what gets stored
+func (l UploadLimits) checkContentType(objectPath string, contentType string) error {
+	if len(l.AllowedContentTypes) == 0 {
+		return nil
+	}
+
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	// Ignore parameters such as "; charset=utf-8"
+	mediaType := strings.ToLower(strings.TrimSpace(strings.SplitN(contentType, ";", 2)[0]))
+
+	for _, allowed := range l.AllowedContentTypes {
+		allowed = strings.ToLower(strings.TrimSpace(allowed))
+		if mediaType == allowed || (strings.HasSuffix(allowed, "/") && strings.HasPrefix(mediaType, allowed)) {
+			return nil
+		}
+	}
+
+	return &ErrContentTypeNotAllowed{ObjectPath: objectPath, ContentType: contentType}
+}
+
+// sizeLimitReader fails a streaming upload of unknown size once it exceeds the maximum object size
+type sizeLimitReader struct {
+	reader     io.Reader
+	objectPath string
+	maxSize    int64
+	read       int64
+	err        *ErrObjectTooLarge // Set once the limit is exceeded
+}
+
+// Read implements io.Reader
+func (r *sizeLimitReader) Read(p []byte) (int, error) {
+	if r.err != nil {
+		return 0, r.err
+	}
+
+	// Read at most one byte past the limit to detect oversized streams
+	if remaining := r.maxSize - r.read + 1; int64(len(p)) > remaining {
+		p = p[:remaining]
+	}
+
+	n, err := r.reader.Read(p)
+	r.read += int64(n)
+	if r.read > r.maxSize {
+		r.err = &ErrObjectTooLarge{ObjectPath: r.objectPath, Size: r.read, MaxSize: r.maxSize}
+		return n, r.err
+	}
+	return n, err
+}
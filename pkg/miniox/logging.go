@@ -0,0 +1,48 @@
+package miniox
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/aeternitas-infinita/rmlog"
+)
+
+// logEnabled reports whether log lines of the given level should be emitted by the client
+func (c *Client) logEnabled(level slog.Level) bool {
+	if c.disableLogging {
+		return false
+	}
+	return c.logLevel == nil || level >= c.logLevel.Level()
+}
+
+// logDebug emits a debug line for a client operation
+func (c *Client) logDebug(ctx context.Context, msg string, attrs ...slog.Attr) {
+	c.log(ctx, slog.LevelDebug, msg, attrs)
+}
+
+// logInfo emits an informational line
+func (c *Client) logInfo(msg string, attrs ...slog.Attr) {
+	c.log(context.Background(), slog.LevelInfo, msg, attrs)
+}
+
+// log emits a line through the configured logger, falling back to the package-wide rmlog logger
+func (c *Client) log(ctx context.Context, level slog.Level, msg string, attrs []slog.Attr) {
+	if !c.logEnabled(level) {
+		return
+	}
+
+	if c.name != "" {
+		attrs = append([]slog.Attr{slog.String("client", c.name)}, attrs...)
+	}
+
+	if c.logger != nil {
+		c.logger.LogAttrs(ctx, level, msg, attrs...)
+		return
+	}
+
+	if level == slog.LevelDebug {
+		rmlog.DebugCtxMin(ctx, msg, attrs...)
+		return
+	}
+	rmlog.InfoMin(msg, attrs...)
+}
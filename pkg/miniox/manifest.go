@@ -0,0 +1,247 @@
+package miniox
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/minio/minio-go/v7"
+)
+
+// manifestAlgorithmHeader starts the comment line naming the hash algorithm of a manifest
+const manifestAlgorithmHeader = "# algorithm: "
+
+// ManifestOptions configures GenerateManifest
+type ManifestOptions struct {
+	Algorithm   HashAlgo // Hash algorithm (default SHA-256)
+	Concurrency int      // Number of objects hashed in parallel (default 4)
+}
+
+// VerifyOptions configures VerifyManifestWithOpts
+type VerifyOptions struct {
+	Concurrency int                 // Number of objects hashed in parallel (default 4)
+	Verified    map[string]struct{} // Keys already verified by an earlier, interrupted run; they are not hashed again
+}
+
+// VerifyReport reports the outcome of a manifest verification
+// Keys are relative to the prefix and sorted
+type VerifyReport struct {
+	Verified   []string         // Keys whose size and hash match, including keys passed in VerifyOptions.Verified
+	Mismatched []string         // Keys whose size or hash differ from the manifest
+	Missing    []string         // Keys listed in the manifest but not stored
+	Extra      []string         // Keys stored but not listed in the manifest
+	Errors     map[string]error // Keys that could not be hashed
+}
+
+// manifestEntry is a line of a manifest
+type manifestEntry struct {
+	hash string
+	size int64
+}
+
+// GenerateManifest writes a checksum manifest of every object under a prefix to w
+// The manifest starts with a "# algorithm: <algo>" line followed by one "<hex hash>  <size>  <key>" line per
+// object, sorted by key, with keys relative to the prefix. Folder markers are left out. Objects are hashed with
+// bounded concurrency, each pinned to the version listed
+func (c *Client) GenerateManifest(ctx context.Context, prefix string, w io.Writer, opts ManifestOptions) (err error) {
+	ctx, span := c.startSpan(ctx, "GenerateManifest", slog.String("prefix", prefix))
+	defer func() { span.End(err) }()
+
+	if err := c.validatePath(ctx, prefix); err != nil {
+		return err
+	}
+
+	algo := opts.Algorithm
+	if algo == "" {
+		algo = HashSHA256
+	}
+	if _, err := newHash(algo); err != nil {
+		return err
+	}
+
+	objects, err := c.listManifestObjects(ctx, prefix)
+	if err != nil {
+		return err
+	}
+	keys := slices.Sorted(maps.Keys(objects))
+	for _, key := range keys {
+		if strings.ContainsAny(key, "\r\n") {
+			return fmt.Errorf("object key %q cannot be written to a manifest", key)
+		}
+	}
+
+	c.logDebug(ctx, "[MinIO] Generating manifest",
+		slog.String("bucket", c.bucketName),
+		slog.String("prefix", prefix),
+		slog.String("algorithm", string(algo)),
+		slog.Int("objects", len(keys)))
+
+	hashes, failed := c.hashManifestObjects(ctx, prefix, keys, objects, algo, opts.Concurrency)
+	if len(failed) > 0 {
+		first := slices.Min(slices.Collect(maps.Keys(failed)))
+		return fmt.Errorf("failed to hash %d objects (e.g. %s): %w", len(failed), first, failed[first])
+	}
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "%s%s\n", manifestAlgorithmHeader, algo)
+	for _, key := range keys {
+		fmt.Fprintf(bw, "%s  %d  %s\n", hashes[key], objects[key].Size, key)
+	}
+	return bw.Flush()
+}
+
+// VerifyManifest re-hashes the objects under a prefix and compares them with a manifest written by GenerateManifest
+// See VerifyManifestWithOpts
+func (c *Client) VerifyManifest(ctx context.Context, prefix string, r io.Reader) (VerifyReport, error) {
+	return c.VerifyManifestWithOpts(ctx, prefix, r, VerifyOptions{})
+}
+
+// VerifyManifestWithOpts verifies a manifest with the given options
+// Sizes are checked from the listing first, so only objects of the expected size are downloaded. When ctx is
+// cancelled the partial report is returned with the context error; pass its Verified keys in opts.Verified to
+// resume without hashing them again. Objects that could not be hashed are listed in report.Errors
+func (c *Client) VerifyManifestWithOpts(ctx context.Context, prefix string, r io.Reader, opts VerifyOptions) (report VerifyReport, err error) {
+	ctx, span := c.startSpan(ctx, "VerifyManifest", slog.String("prefix", prefix))
+	defer func() { span.End(err) }()
+
+	if err := c.validatePath(ctx, prefix); err != nil {
+		return VerifyReport{}, err
+	}
+
+	algo, manifest, err := parseManifest(r)
+	if err != nil {
+		return VerifyReport{}, err
+	}
+
+	objects, err := c.listManifestObjects(ctx, prefix)
+	if err != nil {
+		return VerifyReport{}, err
+	}
+
+	var toHash []string
+	for _, key := range slices.Sorted(maps.Keys(manifest)) {
+		objectInfo, ok := objects[key]
+		switch {
+		case !ok:
+			report.Missing = append(report.Missing, key)
+		case objectInfo.Size != manifest[key].size:
+			report.Mismatched = append(report.Mismatched, key)
+		default:
+			if _, done := opts.Verified[key]; done {
+				report.Verified = append(report.Verified, key)
+				continue
+			}
+			toHash = append(toHash, key)
+		}
+	}
+	for _, key := range slices.Sorted(maps.Keys(objects)) {
+		if _, ok := manifest[key]; !ok {
+			report.Extra = append(report.Extra, key)
+		}
+	}
+
+	c.logDebug(ctx, "[MinIO] Verifying manifest",
+		slog.String("bucket", c.bucketName),
+		slog.String("prefix", prefix),
+		slog.String("algorithm", string(algo)),
+		slog.Int("entries", len(manifest)),
+		slog.Int("toHash", len(toHash)))
+
+	hashes, failed := c.hashManifestObjects(ctx, prefix, toHash, objects, algo, opts.Concurrency)
+	for _, key := range toHash {
+		if hashErr, ok := failed[key]; ok {
+			if errors.Is(hashErr, context.Canceled) || errors.Is(hashErr, context.DeadlineExceeded) {
+				// Not verified yet; a resumed run picks it up
+				continue
+			}
+			if report.Errors == nil {
+				report.Errors = make(map[string]error)
+			}
+			report.Errors[key] = hashErr
+			continue
+		}
+		if strings.EqualFold(hashes[key], manifest[key].hash) {
+			report.Verified = append(report.Verified, key)
+		} else {
+			report.Mismatched = append(report.Mismatched, key)
+		}
+	}
+	slices.Sort(report.Verified)
+	slices.Sort(report.Mismatched)
+
+	return report, ctx.Err()
+}
+
+// listManifestObjects lists the objects under a prefix keyed by their path relative to the prefix, without folder markers
+func (c *Client) listManifestObjects(ctx context.Context, prefix string) (map[string]minio.ObjectInfo, error) {
+	objects, err := c.listSyncObjects(ctx, prefix)
+	if err != nil {
+		return nil, err
+	}
+	maps.DeleteFunc(objects, func(_ string, objectInfo minio.ObjectInfo) bool { return isFolderMarker(objectInfo.Key) })
+	return objects, nil
+}
+
+// hashManifestObjects hashes objects by path relative to the prefix with bounded concurrency
+func (c *Client) hashManifestObjects(ctx context.Context, prefix string, keys []string, objects map[string]minio.ObjectInfo, algo HashAlgo, concurrency int) (map[string]string, map[string]error) {
+	var mu sync.Mutex
+	hashes := make(map[string]string, len(keys))
+	cleanPrefix := strings.Trim(prefix, "/")
+
+	failed := runTransfers(ctx, concurrency, keys, func(key string) error {
+		sum, _, err := c.hashObject(ctx, joinRelative(cleanPrefix, key), algo, objects[key].ETag)
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		hashes[key] = sum
+		mu.Unlock()
+		return nil
+	})
+	return hashes, failed
+}
+
+// parseManifest reads a manifest written by GenerateManifest
+func parseManifest(r io.Reader) (HashAlgo, map[string]manifestEntry, error) {
+	algo := HashSHA256
+	entries := make(map[string]manifestEntry)
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := scanner.Text()
+		if value, ok := strings.CutPrefix(line, manifestAlgorithmHeader); ok {
+			algo = HashAlgo(strings.TrimSpace(value))
+			if _, err := newHash(algo); err != nil {
+				return "", nil, fmt.Errorf("manifest line %d: %w", lineNumber, err)
+			}
+			continue
+		}
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.SplitN(line, "  ", 3)
+		if len(fields) != 3 || fields[0] == "" || fields[2] == "" {
+			return "", nil, fmt.Errorf("manifest line %d: expected \"<hash>  <size>  <key>\"", lineNumber)
+		}
+		size, err := strconv.ParseInt(fields[1], 10, 64)
+		if err != nil {
+			return "", nil, fmt.Errorf("manifest line %d: invalid size: %w", lineNumber, err)
+		}
+		entries[fields[2]] = manifestEntry{hash: fields[0], size: size}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", nil, fmt.Errorf("failed to read manifest: %w", err)
+	}
+
+	return algo, entries, nil
+}
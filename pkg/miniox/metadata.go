@@ -0,0 +1,139 @@
+package miniox
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"maps"
+	"net/http"
+	"strings"
+
+	"github.com/minio/minio-go/v7"
+)
+
+// maxCopyObjectSize is the largest object a single CopyObject request can copy (5 GiB)
+// Larger objects are copied part by part with ComposeObject
+const maxCopyObjectSize = 5 * 1024 * 1024 * 1024
+
+// userMetadataPrefix is the header prefix of user metadata
+const userMetadataPrefix = "X-Amz-Meta-"
+
+// UpdateObjectMetadata replaces the user metadata and optionally the content type of an object without
+// re-uploading it
+// With merge set, metadata is merged into the existing user metadata; otherwise it replaces it. Keys may be
+// given with or without the "X-Amz-Meta-" prefix and are matched case-insensitively. An empty contentType keeps
+// the current one. Other content headers, the storage class and tags are preserved. The object is copied onto
+// itself pinned to its current version, so a concurrent change fails with *ErrPreconditionFailed; objects over
+// 5 GiB are copied part by part server-side
+func (c *Client) UpdateObjectMetadata(ctx context.Context, objectPath string, metadata map[string]string, contentType string, merge bool) (uploadInfo minio.UploadInfo, err error) {
+	ctx, span := c.startSpan(ctx, "UpdateObjectMetadata",
+		slog.String("object", objectPath),
+		slog.Bool("merge", merge))
+	defer func() { span.End(err) }()
+
+	if err := c.validatePath(ctx, objectPath); err != nil {
+		return minio.UploadInfo{}, err
+	}
+
+	info, err := c.statUncached(ctx, "UpdateObjectMetadata", objectPath)
+	if err != nil {
+		return minio.UploadInfo{}, err
+	}
+
+	userMetadata := make(map[string]string, len(info.UserMetadata)+len(metadata)+1)
+	if merge {
+		for key, value := range info.UserMetadata {
+			userMetadata[userMetadataKey(key)] = value
+		}
+	}
+	for key, value := range metadata {
+		key = userMetadataKey(key)
+		if key == "" {
+			return minio.UploadInfo{}, fmt.Errorf("metadata key cannot be empty")
+		}
+		userMetadata[key] = value
+	}
+	if info.StorageClass != "" {
+		// Replacing the metadata would otherwise reset the storage class
+		userMetadata[storageClassHeader] = info.StorageClass
+	}
+
+	c.logDebug(ctx, "[MinIO] Updating object metadata",
+		slog.String("bucket", c.bucketName),
+		slog.String("object", c.buildPath(ctx, objectPath)),
+		slog.Int("keys", len(userMetadata)),
+		slog.String("contentType", contentType),
+		slog.Bool("merge", merge))
+
+	return c.selfCopy(ctx, objectPath, info, userMetadata, contentType)
+}
+
+// userMetadataKey canonicalizes a user metadata key and removes the "X-Amz-Meta-" prefix
+func userMetadataKey(key string) string {
+	key = http.CanonicalHeaderKey(key)
+	return strings.TrimPrefix(key, userMetadataPrefix)
+}
+
+// selfCopyOptions returns copy options that replace the user metadata of an object while keeping its content headers
+func selfCopyOptions(info minio.ObjectInfo, userMetadata map[string]string) minio.CopyDestOptions {
+	return minio.CopyDestOptions{
+		ReplaceMetadata:    true,
+		UserMetadata:       userMetadata,
+		ContentType:        info.ContentType,
+		ContentEncoding:    info.Metadata.Get("Content-Encoding"),
+		ContentDisposition: info.Metadata.Get("Content-Disposition"),
+		ContentLanguage:    info.Metadata.Get("Content-Language"),
+		CacheControl:       info.Metadata.Get("Cache-Control"),
+		Expires:            info.Expires,
+	}
+}
+
+// selfCopy copies an object onto itself with new user metadata, pinned to the stat'd version
+// An empty contentType keeps the current one. Objects over 5 GiB are copied with a multipart ComposeObject, which
+// takes content headers from the metadata and does not copy tags, so both are carried over explicitly
+func (c *Client) selfCopy(ctx context.Context, objectPath string, info minio.ObjectInfo, userMetadata map[string]string, contentType string) (minio.UploadInfo, error) {
+	destOpts := selfCopyOptions(info, userMetadata)
+	if contentType != "" {
+		destOpts.ContentType = contentType
+	}
+
+	if info.Size <= maxCopyObjectSize {
+		return c.CopyObjectConditional(ctx, objectPath, objectPath, minio.CopySrcOptions{MatchETag: info.ETag}, destOpts)
+	}
+
+	objectTags, err := c.GetObjectTagging(ctx, objectPath, minio.GetObjectTaggingOptions{})
+	if err != nil {
+		return minio.UploadInfo{}, fmt.Errorf("failed to get tags of %s: %w", objectPath, err)
+	}
+	destOpts.ReplaceTags = true
+	destOpts.UserTags = objectTags.ToMap()
+
+	destOpts.UserMetadata = maps.Clone(userMetadata)
+	if destOpts.UserMetadata == nil {
+		destOpts.UserMetadata = make(map[string]string, 6)
+	}
+	for header, value := range map[string]string{
+		"Content-Type":        destOpts.ContentType,
+		"Content-Encoding":    destOpts.ContentEncoding,
+		"Content-Disposition": destOpts.ContentDisposition,
+		"Content-Language":    destOpts.ContentLanguage,
+		"Cache-Control":       destOpts.CacheControl,
+	} {
+		if value != "" {
+			destOpts.UserMetadata[header] = value
+		}
+	}
+	if !destOpts.Expires.IsZero() {
+		destOpts.UserMetadata["Expires"] = destOpts.Expires.UTC().Format(http.TimeFormat)
+	}
+
+	uploadInfo, err := c.ComposeObject(ctx, objectPath, []minio.CopySrcOptions{{Object: objectPath, MatchETag: info.ETag}}, destOpts)
+	if isPreconditionFailed(err) {
+		return minio.UploadInfo{}, &ErrPreconditionFailed{
+			ObjectPath: objectPath,
+			Condition:  fmt.Sprintf("If-Match: %q", info.ETag),
+			Err:        err,
+		}
+	}
+	return uploadInfo, err
+}
@@ -0,0 +1,85 @@
+package miniox
+
+import (
+	"context"
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// TransferDirection tells whether observed bytes were sent to or received from the server
+type TransferDirection string
+
+const (
+	BytesUploaded   TransferDirection = "upload"   // Request bodies sent to the server
+	BytesDownloaded TransferDirection = "download" // Response bodies received from the server
+)
+
+// MetricsObserver receives operation and transfer metrics, e.g. to export them to Prometheus
+// Implementations must be safe for concurrent use and should return quickly
+type MetricsObserver interface {
+	// ObserveOperation is called once per client operation when it finishes; err is nil on success.
+	// Listings (ListObjects, ...) finish when their channel is drained. GetObject is lazy and finishes when it
+	// returns, before any request is made; OpenObject finishes once the object is opened, so it reports missing
+	// objects and the latency of the first response. Reading the object body is part of neither
+	ObserveOperation(operation string, duration time.Duration, err error)
+	// ObserveBytes is called once per HTTP request or response body with the number of bytes transferred
+	ObserveBytes(operation string, direction TransferDirection, n int64)
+}
+
+// operationContextKey carries the name of the innermost client operation for transport-level metrics
+type operationContextKey struct{}
+
+// metricsSpan reports the operation duration when the span ends
+type metricsSpan struct {
+	Span
+	observer  MetricsObserver
+	operation string
+	start     time.Time
+}
+
+// End finishes the wrapped span and reports the operation
+func (s metricsSpan) End(err error) {
+	s.Span.End(err)
+	s.observer.ObserveOperation(s.operation, time.Since(s.start), err)
+}
+
+// observeOperation times an operation and tags ctx with its name so transferred bytes can be attributed to it
+func (c *Client) observeOperation(ctx context.Context, operation string, span Span) (context.Context, Span) {
+	ctx = context.WithValue(ctx, operationContextKey{}, operation)
+	return ctx, metricsSpan{Span: span, observer: c.metrics, operation: operation, start: time.Now()}
+}
+
+// byteObserver returns a callback reporting bytes for the operation carried by ctx
+func (t *clientTransport) byteObserver(ctx context.Context, direction TransferDirection) func(int64) {
+	operation, _ := ctx.Value(operationContextKey{}).(string)
+	if operation == "" {
+		operation = "unknown"
+	}
+	return func(n int64) {
+		t.metrics.ObserveBytes(operation, direction, n)
+	}
+}
+
+// countingReader counts the bytes read from a body and reports them once when it is closed
+type countingReader struct {
+	reader  io.ReadCloser
+	observe func(int64)
+	n       atomic.Int64 // The transport may close request bodies from another goroutine
+	once    sync.Once
+}
+
+// Read implements io.Reader
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	r.n.Add(int64(n))
+	return n, err
+}
+
+// Close implements io.Closer
+func (r *countingReader) Close() error {
+	err := r.reader.Close()
+	r.once.Do(func() { r.observe(r.n.Load()) })
+	return err
+}
@@ -0,0 +1,56 @@
+package miniox
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+)
+
+// recordingObserver records the observed operations and their errors
+type recordingObserver struct {
+	mu         sync.Mutex
+	operations map[string][]error
+}
+
+func (o *recordingObserver) ObserveOperation(operation string, _ time.Duration, err error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.operations == nil {
+		o.operations = make(map[string][]error)
+	}
+	o.operations[operation] = append(o.operations[operation], err)
+}
+
+func (o *recordingObserver) ObserveBytes(string, TransferDirection, int64) {}
+
+func TestOpenObjectObservesMissingObject(t *testing.T) {
+	ctx := context.Background()
+	c, fake := newFakeS3Client(t, "")
+	observer := &recordingObserver{}
+	c.metrics = observer
+
+	if _, _, err := c.OpenObject(ctx, "missing.txt", minio.GetObjectOptions{}); err == nil {
+		t.Fatal("OpenObject of a missing object succeeded")
+	}
+
+	fake.put("present.txt", []byte("data"), nil)
+	object, _, err := c.OpenObject(ctx, "present.txt", minio.GetObjectOptions{})
+	if err != nil {
+		t.Fatalf("OpenObject: %v", err)
+	}
+	object.Close()
+
+	errs := observer.operations["OpenObject"]
+	if len(errs) != 2 {
+		t.Fatalf("OpenObject observed %d times, want 2", len(errs))
+	}
+	if minio.ToErrorResponse(errs[0]).Code != "NoSuchKey" {
+		t.Errorf("first OpenObject error = %v, want NoSuchKey", errs[0])
+	}
+	if errs[1] != nil {
+		t.Errorf("second OpenObject error = %v, want nil", errs[1])
+	}
+}
@@ -0,0 +1,178 @@
+package miniox
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/minio/minio-go/v7"
+)
+
+// MirrorOptions configures MirrorPrefix
+type MirrorOptions struct {
+	Concurrency      int  // Number of parallel copies (default 4)
+	DeleteExtraneous bool // Remove destination objects that do not exist under the source prefix
+	DryRun           bool // Only report what would be copied or deleted
+}
+
+// MirrorReport reports the outcome of MirrorPrefix
+// Paths are relative to the source and destination prefixes and sorted
+type MirrorReport struct {
+	Copied  []string         // Objects copied (or to be copied on dry run)
+	Skipped []string         // Objects already present with the same size and ETag
+	Deleted []string         // Destination objects removed (or to be removed on dry run)
+	Failed  map[string]error // Per-path copy and removal errors
+
+	ServerSide       bool  // Whether objects were copied server-side
+	BytesTransferred int64 // Total size of copied objects
+}
+
+// MirrorPrefix makes the objects under dstPrefix on dst match those under srcPrefix on this client
+// When both clients use the same endpoint and bucket, objects are copied server-side; otherwise they are streamed
+// from this client to dst with bounded concurrency, carrying over content headers, user metadata and tags.
+// Objects whose size and ETag already match are skipped; multipart and encrypted ETags usually differ between
+// clusters, so such objects are copied again. Failures do not stop the mirror: they are listed in the report and
+// summarized in the returned error
+func (c *Client) MirrorPrefix(ctx context.Context, dst *Client, srcPrefix, dstPrefix string, opts MirrorOptions) (report MirrorReport, err error) {
+	ctx, span := c.startSpan(ctx, "MirrorPrefix",
+		slog.String("srcPrefix", srcPrefix),
+		slog.String("destBucket", dst.bucketName),
+		slog.String("destPrefix", dstPrefix),
+		slog.Bool("dryRun", opts.DryRun))
+	defer func() { span.End(err) }()
+
+	if err := c.validatePath(ctx, srcPrefix); err != nil {
+		return MirrorReport{}, err
+	}
+	if err := dst.validatePath(ctx, dstPrefix); err != nil {
+		return MirrorReport{}, err
+	}
+
+	srcObjects, err := c.listSyncObjects(ctx, srcPrefix)
+	if err != nil {
+		return MirrorReport{}, err
+	}
+	dstObjects, err := dst.listSyncObjects(ctx, dstPrefix)
+	if err != nil {
+		return MirrorReport{}, err
+	}
+
+	var toCopy, extraneous []string
+	for relativePath, srcInfo := range srcObjects {
+		if dstInfo, ok := dstObjects[relativePath]; ok && dstInfo.Size == srcInfo.Size && dstInfo.ETag == srcInfo.ETag {
+			report.Skipped = append(report.Skipped, relativePath)
+			continue
+		}
+		toCopy = append(toCopy, relativePath)
+	}
+	for relativePath := range dstObjects {
+		if _, ok := srcObjects[relativePath]; !ok {
+			extraneous = append(extraneous, relativePath)
+		}
+	}
+	slices.Sort(toCopy)
+	slices.Sort(extraneous)
+	slices.Sort(report.Skipped)
+
+	report.ServerSide = c.minio.EndpointURL().String() == dst.minio.EndpointURL().String() && c.bucketName == dst.bucketName
+
+	c.logDebug(ctx, "[MinIO] Mirroring prefix",
+		slog.String("bucket", c.bucketName),
+		slog.String("srcPrefix", srcPrefix),
+		slog.String("destBucket", dst.bucketName),
+		slog.String("destPrefix", dstPrefix),
+		slog.Int("copy", len(toCopy)),
+		slog.Int("skip", len(report.Skipped)),
+		slog.Int("extraneous", len(extraneous)),
+		slog.Bool("serverSide", report.ServerSide),
+		slog.Bool("dryRun", opts.DryRun))
+
+	if opts.DryRun {
+		report.Copied = toCopy
+		for _, relativePath := range toCopy {
+			report.BytesTransferred += srcObjects[relativePath].Size
+		}
+		if opts.DeleteExtraneous {
+			report.Deleted = extraneous
+		}
+		return report, nil
+	}
+
+	cleanSrcPrefix := strings.Trim(srcPrefix, "/")
+	cleanDstPrefix := strings.Trim(dstPrefix, "/")
+	failed := runTransfers(ctx, opts.Concurrency, toCopy, func(relativePath string) error {
+		dstPath := joinRelative(cleanDstPrefix, relativePath)
+		if report.ServerSide {
+			return c.mirrorServerSide(ctx, srcObjects[relativePath], dst, dstPath)
+		}
+		return c.mirrorStreamed(ctx, joinRelative(cleanSrcPrefix, relativePath), dst, dstPath)
+	})
+	for _, relativePath := range toCopy {
+		if copyErr, ok := failed[relativePath]; ok {
+			report.fail(relativePath, copyErr)
+			continue
+		}
+		report.Copied = append(report.Copied, relativePath)
+		report.BytesTransferred += srcObjects[relativePath].Size
+	}
+
+	if opts.DeleteExtraneous && len(extraneous) > 0 {
+		deleted, removeFailed := dst.removeExtraneousObjects(ctx, dstPrefix, extraneous, dstObjects)
+		report.Deleted = deleted
+		for relativePath, removeErr := range removeFailed {
+			report.fail(relativePath, removeErr)
+		}
+	}
+
+	if len(report.Failed) > 0 {
+		first := slices.Min(slices.Collect(maps.Keys(report.Failed)))
+		return report, fmt.Errorf("failed to mirror %d paths (e.g. %s): %w", len(report.Failed), first, report.Failed[first])
+	}
+	return report, nil
+}
+
+// fail records a failed path
+func (r *MirrorReport) fail(relativePath string, err error) {
+	if r.Failed == nil {
+		r.Failed = make(map[string]error)
+	}
+	r.Failed[relativePath] = err
+}
+
+// mirrorServerSide copies a listed object (full key) to dst server-side, pinned to the listed ETag
+// Metadata and tags are copied by the server
+func (c *Client) mirrorServerSide(ctx context.Context, srcInfo minio.ObjectInfo, dst *Client, dstPath string) error {
+	srcOpts := minio.CopySrcOptions{
+		Bucket:     c.bucketName,
+		Object:     srcInfo.Key,
+		MatchETag:  srcInfo.ETag,
+		Encryption: c.readSSE(nil),
+	}
+
+	fullDstPath := dst.buildPath(ctx, dstPath)
+	dstOpts := minio.CopyDestOptions{
+		Bucket:     dst.bucketName,
+		Object:     fullDstPath,
+		Encryption: dst.writeSSE(nil),
+	}
+
+	defer dst.invalidateFullPath(fullDstPath)
+	_, err := withRetry(ctx, dst, "CopyObject", func() (minio.UploadInfo, error) {
+		return dst.minio.CopyObject(ctx, dstOpts, srcOpts)
+	})
+	return err
+}
+
+// mirrorStreamed streams an object to dst together with its tags
+func (c *Client) mirrorStreamed(ctx context.Context, srcPath string, dst *Client, dstPath string) error {
+	objectTags, err := c.GetObjectTagging(ctx, srcPath, minio.GetObjectTaggingOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to get tags of %s: %w", srcPath, err)
+	}
+
+	_, err = transferObject(ctx, c, srcPath, dst, dstPath, objectTags.ToMap())
+	return err
+}
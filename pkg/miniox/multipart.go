@@ -0,0 +1,291 @@
+package miniox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+)
+
+// ListIncompleteUploads lists multipart uploads that were started but neither completed nor aborted
+// Keys are relative to the base directory prefix. Incomplete uploads keep their parts stored until removed
+func (c *Client) ListIncompleteUploads(ctx context.Context, prefix string, recursive bool) <-chan minio.ObjectMultipartInfo {
+	ctx, span := c.startSpan(ctx, "ListIncompleteUploads",
+		slog.String("prefix", prefix),
+		slog.Bool("recursive", recursive))
+
+	if err := c.validatePath(ctx, prefix); err != nil {
+		span.End(err)
+		errorCh := make(chan minio.ObjectMultipartInfo, 1)
+		errorCh <- minio.ObjectMultipartInfo{Err: err}
+		close(errorCh)
+		return errorCh
+	}
+
+	fullPrefix := c.buildPrefix(ctx, prefix)
+	c.logDebug(ctx, "[MinIO] Listing incomplete uploads",
+		slog.String("bucket", c.bucketName),
+		slog.String("prefix", fullPrefix),
+		slog.Bool("recursive", recursive))
+
+	uploadCh := c.minio.ListIncompleteUploads(ctx, c.bucketName, fullPrefix, recursive)
+
+	strippedCh := make(chan minio.ObjectMultipartInfo)
+	go func() {
+		var listErr error
+		defer func() { span.End(listErr) }()
+		defer close(strippedCh)
+		for uploadInfo := range uploadCh {
+			if uploadInfo.Err == nil {
+				uploadInfo.Key = c.stripBasePath(ctx, uploadInfo.Key)
+			} else {
+				listErr = uploadInfo.Err
+			}
+			strippedCh <- uploadInfo
+		}
+	}()
+
+	return strippedCh
+}
+
+// RemoveIncompleteUpload aborts all incomplete multipart uploads of an object and frees their stored parts
+func (c *Client) RemoveIncompleteUpload(ctx context.Context, objectPath string) (err error) {
+	ctx, span := c.startOperation(ctx, "RemoveIncompleteUpload", slog.String("object", objectPath))
+	defer func() { span.End(err) }()
+
+	if err := c.validatePath(ctx, objectPath); err != nil {
+		return err
+	}
+
+	fullPath := c.buildPath(ctx, objectPath)
+
+	c.logDebug(ctx, "[MinIO] Removing incomplete upload",
+		slog.String("bucket", c.bucketName),
+		slog.String("object", fullPath))
+
+	_, err = withRetry(ctx, c, "RemoveIncompleteUpload", func() (struct{}, error) {
+		return struct{}{}, c.minio.RemoveIncompleteUpload(ctx, c.bucketName, fullPath)
+	})
+	return err
+}
+
+// RemoveIncompleteUploads aborts incomplete multipart uploads under a prefix initiated more than olderThan ago
+// Returns the number of aborted uploads
+func (c *Client) RemoveIncompleteUploads(ctx context.Context, prefix string, olderThan time.Duration) (int, error) {
+	return c.RemoveIncompleteUploadsWithOpts(ctx, prefix, olderThan, RemoveOptions{})
+}
+
+// RemoveIncompleteUploadsWithOpts aborts old incomplete multipart uploads with the given options
+// Uploads are aborted one by one by upload ID, so newer uploads of the same object keep running. With DryRun the
+// matching uploads are only counted and logged; with ContinueOnError failures are joined into the returned error
+// after all uploads were tried. Concurrency is not used. Each aborted upload ID is logged
+func (c *Client) RemoveIncompleteUploadsWithOpts(ctx context.Context, prefix string, olderThan time.Duration, opts RemoveOptions) (removed int, err error) {
+	ctx, span := c.startSpan(ctx, "RemoveIncompleteUploads",
+		slog.String("prefix", prefix),
+		slog.Duration("olderThan", olderThan),
+		slog.Bool("dryRun", opts.DryRun))
+	defer func() { span.End(err) }()
+
+	cutoff := time.Now().Add(-olderThan)
+
+	var stale []minio.ObjectMultipartInfo
+	for uploadInfo := range c.ListIncompleteUploads(ctx, prefix, true) {
+		if uploadInfo.Err != nil {
+			return 0, uploadInfo.Err
+		}
+		if uploadInfo.Initiated.Before(cutoff) {
+			stale = append(stale, uploadInfo)
+		}
+	}
+
+	core := minio.Core{Client: c.minio}
+	var errs []error
+	message := "[MinIO] Aborted incomplete upload"
+	if opts.DryRun {
+		message = "[MinIO] Incomplete upload would be aborted"
+	}
+
+	for _, uploadInfo := range stale {
+		if !opts.DryRun {
+			fullPath := c.buildPath(ctx, uploadInfo.Key)
+			_, err := withRetry(ctx, c, "AbortMultipartUpload", func() (struct{}, error) {
+				return struct{}{}, core.AbortMultipartUpload(ctx, c.bucketName, fullPath, uploadInfo.UploadID)
+			})
+			// An upload completed or aborted in the meantime is no longer incomplete
+			if err != nil && minio.ToErrorResponse(err).Code != "NoSuchUpload" {
+				err = fmt.Errorf("failed to abort upload %s of %s: %w", uploadInfo.UploadID, uploadInfo.Key, err)
+				if !opts.ContinueOnError {
+					return removed, err
+				}
+				errs = append(errs, err)
+				continue
+			}
+		}
+
+		c.logInfo(message,
+			slog.String("bucket", c.bucketName),
+			slog.String("object", uploadInfo.Key),
+			slog.String("uploadId", uploadInfo.UploadID),
+			slog.Time("initiated", uploadInfo.Initiated),
+			slog.Bool("dryRun", opts.DryRun))
+		removed++
+	}
+
+	return removed, errors.Join(errs...)
+}
+
+// maxUploadParts is the maximum number of parts of a multipart upload
+const maxUploadParts = 10000
+
+// NewMultipartUpload starts a client-driven multipart upload and returns its upload ID
+// Parts are then uploaded directly, e.g. from a browser via PresignedUploadPartURL, and the upload is finished
+// with CompleteMultipartUpload or abandoned with AbortMultipartUpload. The default encryption and storage class
+// and the allowed content types are applied as for PutObject; Config.MaxObjectSize is checked on completion
+func (c *Client) NewMultipartUpload(ctx context.Context, objectPath string, opts minio.PutObjectOptions) (uploadID string, err error) {
+	ctx, span := c.startOperation(ctx, "NewMultipartUpload", slog.String("object", objectPath))
+	defer func() { span.End(err) }()
+
+	if err := c.validatePath(ctx, objectPath); err != nil {
+		return "", err
+	}
+	if err := c.uploadLimits.checkContentType(objectPath, opts.ContentType); err != nil {
+		return "", err
+	}
+
+	opts.ServerSideEncryption = c.writeSSE(opts.ServerSideEncryption)
+	opts.StorageClass = c.writeStorageClass(opts.StorageClass)
+
+	fullPath := c.buildPath(ctx, objectPath)
+
+	c.logDebug(ctx, "[MinIO] Starting multipart upload",
+		slog.String("bucket", c.bucketName),
+		slog.String("object", fullPath),
+		slog.String("contentType", opts.ContentType))
+
+	return minio.Core{Client: c.minio}.NewMultipartUpload(ctx, c.bucketName, fullPath, opts)
+}
+
+// PresignedUploadPartURL generates a presigned PUT URL uploading one part of a multipart upload
+// Part numbers run from 1 to 10000; the ETag response header of the PUT must be passed to CompleteMultipartUpload
+func (c *Client) PresignedUploadPartURL(ctx context.Context, objectPath string, uploadID string, partNumber int, expiry time.Duration) (*url.URL, error) {
+	if uploadID == "" {
+		return nil, fmt.Errorf("upload ID is required")
+	}
+	if partNumber < 1 || partNumber > maxUploadParts {
+		return nil, fmt.Errorf("part number must be between 1 and %d, got %d", maxUploadParts, partNumber)
+	}
+
+	reqParams := url.Values{}
+	reqParams.Set("uploadId", uploadID)
+	reqParams.Set("partNumber", strconv.Itoa(partNumber))
+	return c.PresignMethod(ctx, http.MethodPut, objectPath, expiry, reqParams)
+}
+
+// CompleteMultipartUpload assembles the uploaded parts into the object
+// Parts may be given in any order. With Config.MaxObjectSize set, the stored part sizes are checked first and an
+// oversized upload fails with *ErrObjectTooLarge; it is left in place so the caller can abort it
+func (c *Client) CompleteMultipartUpload(ctx context.Context, objectPath string, uploadID string, parts []minio.CompletePart) (uploadInfo minio.UploadInfo, err error) {
+	ctx, span := c.startOperation(ctx, "CompleteMultipartUpload",
+		slog.String("object", objectPath),
+		slog.Int("parts", len(parts)))
+	defer func() { span.End(err) }()
+
+	if err := c.validatePath(ctx, objectPath); err != nil {
+		return minio.UploadInfo{}, err
+	}
+	if uploadID == "" {
+		return minio.UploadInfo{}, fmt.Errorf("upload ID is required")
+	}
+	if len(parts) == 0 {
+		return minio.UploadInfo{}, fmt.Errorf("at least one part is required")
+	}
+
+	fullPath := c.buildPath(ctx, objectPath)
+	core := minio.Core{Client: c.minio}
+
+	if maxSize := c.uploadLimits.MaxObjectSize; maxSize > 0 {
+		size, err := c.uploadedPartsSize(ctx, core, fullPath, uploadID, parts)
+		if err != nil {
+			return minio.UploadInfo{}, err
+		}
+		if size > maxSize {
+			return minio.UploadInfo{}, &ErrObjectTooLarge{ObjectPath: objectPath, Size: size, MaxSize: maxSize}
+		}
+	}
+
+	sortedParts := slices.Clone(parts)
+	slices.SortFunc(sortedParts, func(a, b minio.CompletePart) int { return a.PartNumber - b.PartNumber })
+
+	c.logDebug(ctx, "[MinIO] Completing multipart upload",
+		slog.String("bucket", c.bucketName),
+		slog.String("object", fullPath),
+		slog.Int("parts", len(parts)))
+
+	defer c.invalidateFullPath(fullPath)
+	uploadInfo, err = core.CompleteMultipartUpload(ctx, c.bucketName, fullPath, uploadID, sortedParts, minio.PutObjectOptions{})
+	if err != nil {
+		return uploadInfo, err
+	}
+
+	// Strip base path from returned upload info
+	uploadInfo.Key = c.stripBasePath(ctx, uploadInfo.Key)
+	return uploadInfo, nil
+}
+
+// AbortMultipartUpload aborts a multipart upload and frees its stored parts
+func (c *Client) AbortMultipartUpload(ctx context.Context, objectPath string, uploadID string) (err error) {
+	ctx, span := c.startOperation(ctx, "AbortMultipartUpload", slog.String("object", objectPath))
+	defer func() { span.End(err) }()
+
+	if err := c.validatePath(ctx, objectPath); err != nil {
+		return err
+	}
+	if uploadID == "" {
+		return fmt.Errorf("upload ID is required")
+	}
+
+	fullPath := c.buildPath(ctx, objectPath)
+
+	c.logDebug(ctx, "[MinIO] Aborting multipart upload",
+		slog.String("bucket", c.bucketName),
+		slog.String("object", fullPath),
+		slog.String("uploadID", uploadID))
+
+	_, err = withRetry(ctx, c, "AbortMultipartUpload", func() (struct{}, error) {
+		return struct{}{}, minio.Core{Client: c.minio}.AbortMultipartUpload(ctx, c.bucketName, fullPath, uploadID)
+	})
+	return err
+}
+
+// uploadedPartsSize returns the total stored size of the given parts of a multipart upload
+func (c *Client) uploadedPartsSize(ctx context.Context, core minio.Core, fullPath string, uploadID string, parts []minio.CompletePart) (int64, error) {
+	wanted := make(map[int]struct{}, len(parts))
+	for _, part := range parts {
+		wanted[part.PartNumber] = struct{}{}
+	}
+
+	var size int64
+	marker := 0
+	for {
+		result, err := core.ListObjectParts(ctx, c.bucketName, fullPath, uploadID, marker, 1000)
+		if err != nil {
+			return 0, fmt.Errorf("failed to list uploaded parts: %w", err)
+		}
+		for _, part := range result.ObjectParts {
+			if _, ok := wanted[part.PartNumber]; ok {
+				size += part.Size
+			}
+		}
+		if !result.IsTruncated {
+			return size, nil
+		}
+		marker = result.NextPartNumberMarker
+	}
+}
@@ -0,0 +1,188 @@
+package miniox
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"time"
+
+	"github.com/minio/minio-go/v7/pkg/notification"
+)
+
+const (
+	// defaultNotificationRetries is the number of reconnect attempts for notification streams
+	defaultNotificationRetries = 5
+
+	// notificationBackoffBase and notificationBackoffMax bound the delay between reconnect attempts
+	notificationBackoffBase = 500 * time.Millisecond
+	notificationBackoffMax  = 30 * time.Second
+)
+
+// NotificationEvent represents a single bucket event with a key relative to the base directory prefix
+type NotificationEvent struct {
+	Key       string    // Relative object key
+	EventName string    // Event type, e.g. "s3:ObjectCreated:Put"
+	Size      int64     // Object size in bytes
+	ETag      string    // Object ETag
+	VersionID string    // Object version ID, if versioning is enabled
+	EventTime time.Time // Time the event occurred
+	Err       error     // Set when the stream failed permanently; no further events follow
+}
+
+// ListenNotifications streams bucket events for objects under a relative prefix (MinIO extension)
+// Transient stream errors are retried with exponential backoff up to the configured number of retries,
+// after which a final event with Err set is delivered. The channel is closed when ctx is cancelled.
+func (c *Client) ListenNotifications(ctx context.Context, relativePrefix string, suffix string, events []string) <-chan NotificationEvent {
+	eventCh := make(chan NotificationEvent)
+
+	if err := c.validatePath(ctx, relativePrefix); err != nil {
+		go func() {
+			defer close(eventCh)
+			select {
+			case eventCh <- NotificationEvent{Err: err}:
+			case <-ctx.Done():
+			}
+		}()
+		return eventCh
+	}
+
+	fullPrefix := c.buildPrefix(ctx, relativePrefix)
+
+	c.logDebug(ctx, "[MinIO] Listening for bucket notifications",
+		slog.String("bucket", c.bucketName),
+		slog.String("prefix", fullPrefix),
+		slog.String("suffix", suffix),
+		slog.Any("events", events))
+
+	go func() {
+		defer close(eventCh)
+
+		retries := 0
+		for {
+			streamErr := c.receiveNotifications(ctx, fullPrefix, suffix, events, eventCh, &retries)
+			if ctx.Err() != nil {
+				return
+			}
+
+			if streamErr == nil {
+				streamErr = fmt.Errorf("notification stream closed by server")
+			}
+
+			retries++
+			if retries > c.notificationRetries {
+				select {
+				case eventCh <- NotificationEvent{Err: streamErr}:
+				case <-ctx.Done():
+				}
+				return
+			}
+
+			// Cap the exponent so a large retry count cannot overflow the shift into a zero or negative delay
+			backoff := min(notificationBackoffBase<<min(retries-1, 16), notificationBackoffMax)
+			c.logDebug(ctx, "[MinIO] Reconnecting notification stream",
+				slog.String("bucket", c.bucketName),
+				slog.Int("attempt", retries),
+				slog.Duration("backoff", backoff))
+
+			select {
+			case <-time.After(backoff):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return eventCh
+}
+
+// ListenBucketNotification streams raw bucket notifications for objects under a prefix (MinIO extension)
+// The prefix is resolved with the base directory prefix and object keys in emitted records are decoded
+// and made relative. Unlike ListenNotifications, stream errors are passed through without reconnecting
+func (c *Client) ListenBucketNotification(ctx context.Context, prefix string, suffix string, events []string) <-chan notification.Info {
+	if err := c.validatePath(ctx, prefix); err != nil {
+		// Return a channel with the error
+		errorCh := make(chan notification.Info, 1)
+		errorCh <- notification.Info{Err: err}
+		close(errorCh)
+		return errorCh
+	}
+
+	fullPrefix := c.buildPrefix(ctx, prefix)
+
+	c.logDebug(ctx, "[MinIO] Listening for raw bucket notifications",
+		slog.String("bucket", c.bucketName),
+		slog.String("prefix", fullPrefix),
+		slog.String("suffix", suffix))
+
+	infoCh := c.minio.ListenBucketNotification(ctx, c.bucketName, fullPrefix, suffix, events)
+
+	// Create a new channel to strip base paths from returned records
+	strippedCh := make(chan notification.Info)
+
+	go func() {
+		defer close(strippedCh)
+		for info := range infoCh {
+			for i := range info.Records {
+				info.Records[i].S3.Object.Key = c.stripBasePath(ctx, decodeNotificationKey(info.Records[i].S3.Object.Key))
+			}
+
+			select {
+			case strippedCh <- info:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return strippedCh
+}
+
+// receiveNotifications forwards the events of a single notification stream to eventCh until the stream fails or
+// ctx is done, resetting retries whenever events arrive. The stream gets its own context that is cancelled on return:
+// minio-go keeps reconnecting after reporting an error, so an abandoned stream would leak its goroutine and HTTP response
+func (c *Client) receiveNotifications(ctx context.Context, fullPrefix string, suffix string, events []string, eventCh chan<- NotificationEvent, retries *int) error {
+	streamCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	for info := range c.minio.ListenBucketNotification(streamCtx, c.bucketName, fullPrefix, suffix, events) {
+		if info.Err != nil {
+			return info.Err
+		}
+
+		// Receiving events means the stream is healthy again
+		*retries = 0
+		for _, record := range info.Records {
+			select {
+			case eventCh <- c.toNotificationEvent(ctx, record):
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+	}
+
+	return nil
+}
+
+// toNotificationEvent converts a raw notification record into a NotificationEvent with a relative key
+func (c *Client) toNotificationEvent(ctx context.Context, record notification.Event) NotificationEvent {
+	eventTime, _ := time.Parse(time.RFC3339Nano, record.EventTime)
+
+	return NotificationEvent{
+		Key:       c.stripBasePath(ctx, decodeNotificationKey(record.S3.Object.Key)),
+		EventName: record.EventName,
+		Size:      record.S3.Object.Size,
+		ETag:      record.S3.Object.ETag,
+		VersionID: record.S3.Object.VersionID,
+		EventTime: eventTime,
+	}
+}
+
+// decodeNotificationKey decodes a URL-encoded object key from a notification record
+func decodeNotificationKey(key string) string {
+	decoded, err := url.QueryUnescape(key)
+	if err != nil {
+		return key
+	}
+	return decoded
+}
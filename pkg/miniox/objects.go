@@ -1,106 +1,293 @@
 package miniox
 
 import (
+	"bytes"
 	"context"
+	"errors"
+	"fmt"
 	"io"
 	"log/slog"
+	"mime"
+	"net/http"
+	"path"
 
-	"github.com/aeternitas-infinita/rmlog"
 	"github.com/minio/minio-go/v7"
 )
 
 // StatObject performs StatObject with automatic bucket name and path prefix handling
-func (c *Client) StatObject(ctx context.Context, objectPath string, opts minio.StatObjectOptions) (minio.ObjectInfo, error) {
-	if err := c.ValidatePath(objectPath); err != nil {
+func (c *Client) StatObject(ctx context.Context, objectPath string, opts minio.StatObjectOptions) (info minio.ObjectInfo, err error) {
+	ctx, span := c.startOperation(ctx, "StatObject", slog.String("object", objectPath))
+	defer func() { span.End(err) }()
+
+	if err := c.validatePath(ctx, objectPath); err != nil {
 		return minio.ObjectInfo{}, err
 	}
 
-	fullPath := c.buildPath(objectPath)
-	rmlog.DebugCtxMin(ctx, "[MinIO] Getting object info",
+	fullPath := c.buildPath(ctx, objectPath)
+
+	// Only plain stats of the latest version are cached
+	cacheable := opts.VersionID == "" && opts.PartNumber == 0 && len(opts.Header()) == 0
+	if cacheable {
+		if cached, ok := c.statCache.get(c.statCacheKey(fullPath)); ok {
+			cached.Key = c.stripBasePath(ctx, cached.Key)
+			span.SetAttributes(slog.Bool("cached", true))
+			return cached, nil
+		}
+	}
+
+	opts.ServerSideEncryption = c.readSSE(opts.ServerSideEncryption)
+
+	c.logDebug(ctx, "[MinIO] Getting object info",
 		slog.String("bucket", c.bucketName),
 		slog.String("object", fullPath))
 
-	info, err := c.minio.StatObject(ctx, c.bucketName, fullPath, opts)
+	info, err = withRetry(ctx, c, "StatObject", func() (minio.ObjectInfo, error) {
+		return c.minio.StatObject(ctx, c.bucketName, fullPath, opts)
+	})
 	if err != nil {
 		return info, err
 	}
 
+	if cacheable {
+		c.statCache.set(c.statCacheKey(fullPath), info)
+	}
+
 	// Strip base path from returned object info to maintain relative paths for external usage
-	info.Key = c.stripBasePath(info.Key)
+	info.Key = c.stripBasePath(ctx, info.Key)
+	span.SetAttributes(slog.Int64("size", info.Size))
 	return info, nil
 }
 
+// GetObjectAttributes gets structured object attributes (checksums, parts, storage class) with automatic path prefix handling
+// Set opts.MaxParts to include part details, e.g. to verify multipart uploads. The response carries no object key
+func (c *Client) GetObjectAttributes(ctx context.Context, objectPath string, opts minio.ObjectAttributesOptions) (attributes *minio.ObjectAttributes, err error) {
+	ctx, span := c.startOperation(ctx, "GetObjectAttributes", slog.String("object", objectPath))
+	defer func() { span.End(err) }()
+
+	if err := c.validatePath(ctx, objectPath); err != nil {
+		return nil, err
+	}
+
+	fullPath := c.buildPath(ctx, objectPath)
+	opts.ServerSideEncryption = c.readSSE(opts.ServerSideEncryption)
+
+	c.logDebug(ctx, "[MinIO] Getting object attributes",
+		slog.String("bucket", c.bucketName),
+		slog.String("object", fullPath))
+
+	return withRetry(ctx, c, "GetObjectAttributes", func() (*minio.ObjectAttributes, error) {
+		return c.minio.GetObjectAttributes(ctx, c.bucketName, fullPath, opts)
+	})
+}
+
 // GetObject performs GetObject with automatic bucket name and path prefix handling
-func (c *Client) GetObject(ctx context.Context, objectPath string, opts minio.GetObjectOptions) (*minio.Object, error) {
-	if err := c.ValidatePath(objectPath); err != nil {
+// The returned object is lazy: no request is made until the first read, so transient errors are not retried here
+// and neither tracing nor metrics see them (use OpenObject for an eager, retried and measured initial request)
+func (c *Client) GetObject(ctx context.Context, objectPath string, opts minio.GetObjectOptions) (object *minio.Object, err error) {
+	ctx, span := c.startSpan(ctx, "GetObject", slog.String("object", objectPath))
+	defer func() { span.End(err) }()
+
+	if err := c.validatePath(ctx, objectPath); err != nil {
 		return nil, err
 	}
 
-	fullPath := c.buildPath(objectPath)
-	rmlog.DebugCtxMin(ctx, "[MinIO] Getting object",
+	opts.ServerSideEncryption = c.readSSE(opts.ServerSideEncryption)
+
+	fullPath := c.buildPath(ctx, objectPath)
+	c.logDebug(ctx, "[MinIO] Getting object",
 		slog.String("bucket", c.bucketName),
 		slog.String("object", fullPath))
 
 	return c.minio.GetObject(ctx, c.bucketName, fullPath, opts)
 }
 
+// OpenObject opens an object for reading and fails immediately if it cannot be read (e.g. it does not exist)
+// Unlike GetObject, which defers errors until the first Read, the object is requested eagerly.
+// The caller must close the returned reader
+func (c *Client) OpenObject(ctx context.Context, objectPath string, opts minio.GetObjectOptions) (reader io.ReadCloser, info minio.ObjectInfo, err error) {
+	// No operation timeout: the returned reader keeps using ctx after OpenObject returns
+	ctx, span := c.startSpan(ctx, "OpenObject", slog.String("object", objectPath))
+	defer func() { span.End(err) }()
+
+	var object *minio.Object
+	info, err = withRetry(ctx, c, "OpenObject", func() (minio.ObjectInfo, error) {
+		var err error
+		object, err = c.GetObject(ctx, objectPath, opts)
+		if err != nil {
+			return minio.ObjectInfo{}, err
+		}
+
+		// Stat issues the first request of the lazy object and surfaces any error
+		info, err := object.Stat()
+		if err != nil {
+			object.Close()
+			return minio.ObjectInfo{}, err
+		}
+		return info, nil
+	})
+	if err != nil {
+		return nil, minio.ObjectInfo{}, err
+	}
+
+	info.Key = c.stripBasePath(ctx, info.Key)
+	return object, info, nil
+}
+
+// GetObjectSeeker opens an object like OpenObject and returns it as a standard io.ReadSeekCloser
+// The object info carries the size and modification time, e.g. for
+// http.ServeContent(w, r, info.Key, info.LastModified, object). Each seek followed by a read issues a new ranged
+// request. The caller must close the returned reader
+func (c *Client) GetObjectSeeker(ctx context.Context, objectPath string, opts minio.GetObjectOptions) (io.ReadSeekCloser, minio.ObjectInfo, error) {
+	object, info, err := c.OpenObject(ctx, objectPath, opts)
+	if err != nil {
+		return nil, minio.ObjectInfo{}, err
+	}
+	return object.(*minio.Object), info, nil
+}
+
 // PutObject performs PutObject with automatic bucket name and path prefix handling
+// The client upload limits (Config.MaxObjectSize, Config.AllowedContentTypes) are enforced
 func (c *Client) PutObject(ctx context.Context, objectPath string, reader io.Reader, objectSize int64, opts minio.PutObjectOptions) (minio.UploadInfo, error) {
-	if err := c.ValidatePath(objectPath); err != nil {
+	return c.putObject(ctx, objectPath, reader, objectSize, opts, c.uploadLimits)
+}
+
+// PutObjectWithLimits performs PutObject enforcing the given upload limits instead of the client ones
+// Oversized uploads fail with *ErrObjectTooLarge, even when streaming with an unknown size, and disallowed
+// content types fail with *ErrContentTypeNotAllowed before any data is transferred
+func (c *Client) PutObjectWithLimits(ctx context.Context, objectPath string, reader io.Reader, objectSize int64, opts minio.PutObjectOptions, limits UploadLimits) (minio.UploadInfo, error) {
+	return c.putObject(ctx, objectPath, reader, objectSize, opts, limits)
+}
+
+// putObject uploads an object with automatic path prefix handling, content type detection and upload limits
+func (c *Client) putObject(ctx context.Context, objectPath string, reader io.Reader, objectSize int64, opts minio.PutObjectOptions, limits UploadLimits) (uploadInfo minio.UploadInfo, err error) {
+	ctx, span := c.startOperation(ctx, "PutObject",
+		slog.String("object", objectPath),
+		slog.Int64("size", objectSize))
+	defer func() { span.End(err) }()
+
+	if err := c.validatePath(ctx, objectPath); err != nil {
 		return minio.UploadInfo{}, err
 	}
 
-	fullPath := c.buildPath(objectPath)
-	rmlog.DebugCtxMin(ctx, "[MinIO] Putting object",
+	if opts.ContentType == "" && c.autoDetectContentType {
+		contentType, detectedReader, err := detectContentType(objectPath, reader, objectSize)
+		if err != nil {
+			return minio.UploadInfo{}, fmt.Errorf("failed to detect content type: %w", err)
+		}
+		opts.ContentType = contentType
+		reader = detectedReader
+	}
+
+	if err := limits.checkContentType(objectPath, opts.ContentType); err != nil {
+		return minio.UploadInfo{}, err
+	}
+
+	var sizeLimiter *sizeLimitReader
+	if limits.MaxObjectSize > 0 {
+		if objectSize > limits.MaxObjectSize {
+			return minio.UploadInfo{}, &ErrObjectTooLarge{ObjectPath: objectPath, Size: objectSize, MaxSize: limits.MaxObjectSize}
+		}
+		if objectSize < 0 && reader != nil {
+			sizeLimiter = &sizeLimitReader{reader: reader, objectPath: objectPath, maxSize: limits.MaxObjectSize}
+			reader = sizeLimiter
+		}
+	}
+
+	opts.ServerSideEncryption = c.writeSSE(opts.ServerSideEncryption)
+	opts.StorageClass = c.writeStorageClass(opts.StorageClass)
+
+	fullPath := c.buildPath(ctx, objectPath)
+	c.logDebug(ctx, "[MinIO] Putting object",
 		slog.String("bucket", c.bucketName),
 		slog.String("object", fullPath),
-		slog.Int64("size", objectSize))
+		slog.Int64("size", objectSize),
+		slog.String("contentType", opts.ContentType))
 
-	uploadInfo, err := c.minio.PutObject(ctx, c.bucketName, fullPath, reader, objectSize, opts)
+	defer c.invalidateFullPath(fullPath)
+	uploadInfo, err = c.putObjectWithRetry(ctx, fullPath, reader, objectSize, opts)
 	if err != nil {
+		if sizeLimiter != nil && sizeLimiter.err != nil {
+			return minio.UploadInfo{}, sizeLimiter.err
+		}
 		return uploadInfo, err
 	}
 
 	// Strip base path from returned upload info
-	uploadInfo.Key = c.stripBasePath(uploadInfo.Key)
+	uploadInfo.Key = c.stripBasePath(ctx, uploadInfo.Key)
 	return uploadInfo, nil
 }
 
 // RemoveObject performs RemoveObject with automatic bucket name and path prefix handling
-func (c *Client) RemoveObject(ctx context.Context, objectPath string, opts minio.RemoveObjectOptions) error {
-	if err := c.ValidatePath(objectPath); err != nil {
+func (c *Client) RemoveObject(ctx context.Context, objectPath string, opts minio.RemoveObjectOptions) (err error) {
+	ctx, span := c.startOperation(ctx, "RemoveObject", slog.String("object", objectPath))
+	defer func() { span.End(err) }()
+
+	if err := c.validatePath(ctx, objectPath); err != nil {
 		return err
 	}
 
-	fullPath := c.buildPath(objectPath)
-	rmlog.DebugCtxMin(ctx, "[MinIO] Removing object",
+	fullPath := c.buildPath(ctx, objectPath)
+	c.logDebug(ctx, "[MinIO] Removing object",
 		slog.String("bucket", c.bucketName),
 		slog.String("object", fullPath))
 
-	return c.minio.RemoveObject(ctx, c.bucketName, fullPath, opts)
+	defer c.invalidateFullPath(fullPath)
+	_, err = withRetry(ctx, c, "RemoveObject", func() (struct{}, error) {
+		return struct{}{}, c.minio.RemoveObject(ctx, c.bucketName, fullPath, opts)
+	})
+	return err
+}
+
+// ListObjectsOpts configures ListObjectsWithOpts
+type ListObjectsOpts struct {
+	Recursive    bool // List all objects under the prefix instead of a single level
+	WithMetadata bool // Return user metadata inline in ObjectInfo.UserMetadata (MinIO extension)
+	WithVersions bool // List all object versions and delete markers instead of only the latest versions
+
+	HideFolderMarkers bool                        // Skip folder marker objects
+	Filter            func(minio.ObjectInfo) bool // Only emit objects for which Filter returns true; receives keys relative to the base directory prefix (nil emits everything)
 }
 
 // ListObjects lists objects with automatic bucket name and path prefix handling
 func (c *Client) ListObjects(ctx context.Context, prefix string, recursive bool) <-chan minio.ObjectInfo {
-	if prefix != "" {
-		if err := c.ValidatePath(prefix); err != nil {
-			// Return a channel with the error
-			errorCh := make(chan minio.ObjectInfo, 1)
-			errorCh <- minio.ObjectInfo{Err: err}
-			close(errorCh)
-			return errorCh
-		}
+	return c.ListObjectsWithOpts(ctx, prefix, ListObjectsOpts{Recursive: recursive})
+}
+
+// ListObjectsWhere lists objects for which filter returns true with automatic bucket name and path prefix handling
+// The filter receives keys relative to the base directory prefix; a nil filter emits everything
+func (c *Client) ListObjectsWhere(ctx context.Context, prefix string, recursive bool, filter func(minio.ObjectInfo) bool) <-chan minio.ObjectInfo {
+	return c.ListObjectsWithOpts(ctx, prefix, ListObjectsOpts{Recursive: recursive, Filter: filter})
+}
+
+// ListObjectsWithOpts lists objects with additional options and automatic bucket name and path prefix handling
+// Setting WithMetadata avoids a StatObject call per object when user metadata is needed
+func (c *Client) ListObjectsWithOpts(ctx context.Context, prefix string, listOpts ListObjectsOpts) <-chan minio.ObjectInfo {
+	ctx, span := c.startSpan(ctx, "ListObjects",
+		slog.String("prefix", prefix),
+		slog.Bool("recursive", listOpts.Recursive))
+
+	if err := c.validatePath(ctx, prefix); err != nil {
+		span.End(err)
+		// Return a channel with the error
+		errorCh := make(chan minio.ObjectInfo, 1)
+		errorCh <- minio.ObjectInfo{Err: err}
+		close(errorCh)
+		return errorCh
 	}
 
-	fullPrefix := c.buildPath(prefix)
-	rmlog.DebugCtxMin(ctx, "[MinIO] Listing objects",
+	fullPrefix := c.buildPath(ctx, prefix)
+	c.logDebug(ctx, "[MinIO] Listing objects",
 		slog.String("bucket", c.bucketName),
 		slog.String("prefix", fullPrefix),
-		slog.Bool("recursive", recursive))
+		slog.Bool("recursive", listOpts.Recursive),
+		slog.Bool("withMetadata", listOpts.WithMetadata),
+		slog.Bool("withVersions", listOpts.WithVersions))
 
 	opts := minio.ListObjectsOptions{
-		Prefix:    fullPrefix,
-		Recursive: recursive,
+		Prefix:       fullPrefix,
+		Recursive:    listOpts.Recursive,
+		WithMetadata: listOpts.WithMetadata,
+		WithVersions: listOpts.WithVersions,
 	}
 
 	objectCh := c.minio.ListObjects(ctx, c.bucketName, opts)
@@ -109,10 +296,20 @@ func (c *Client) ListObjects(ctx context.Context, prefix string, recursive bool)
 	strippedCh := make(chan minio.ObjectInfo)
 
 	go func() {
+		var listErr error
+		defer func() { span.End(listErr) }()
 		defer close(strippedCh)
 		for objectInfo := range objectCh {
 			if objectInfo.Err == nil {
-				objectInfo.Key = c.stripBasePath(objectInfo.Key)
+				if listOpts.HideFolderMarkers && isFolderMarker(objectInfo.Key) {
+					continue
+				}
+				objectInfo.Key = c.stripBasePath(ctx, objectInfo.Key)
+				if listOpts.Filter != nil && !listOpts.Filter(objectInfo) {
+					continue
+				}
+			} else {
+				listErr = objectInfo.Err
 			}
 			strippedCh <- objectInfo
 		}
@@ -122,38 +319,176 @@ func (c *Client) ListObjects(ctx context.Context, prefix string, recursive bool)
 }
 
 // CopyObject copies an object from source to destination with automatic path handling
-func (c *Client) CopyObject(ctx context.Context, destObjectPath string, srcObjectPath string, opts minio.CopyDestOptions) (minio.UploadInfo, error) {
-	if err := c.ValidatePath(destObjectPath); err != nil {
+// The destination bucket and object in opts are always overridden by the configured bucket and destObjectPath
+func (c *Client) CopyObject(ctx context.Context, destObjectPath string, srcObjectPath string, opts minio.CopyDestOptions) (uploadInfo minio.UploadInfo, err error) {
+	ctx, span := c.startOperation(ctx, "CopyObject",
+		slog.String("src", srcObjectPath),
+		slog.String("dest", destObjectPath))
+	defer func() { span.End(err) }()
+
+	if err := c.validatePath(ctx, destObjectPath); err != nil {
 		return minio.UploadInfo{}, err
 	}
-	if err := c.ValidatePath(srcObjectPath); err != nil {
+	if err := c.validatePath(ctx, srcObjectPath); err != nil {
 		return minio.UploadInfo{}, err
 	}
 
-	fullDestPath := c.buildPath(destObjectPath)
-	fullSrcPath := c.buildPath(srcObjectPath)
+	fullDestPath := c.buildPath(ctx, destObjectPath)
+	fullSrcPath := c.buildPath(ctx, srcObjectPath)
 
-	rmlog.DebugCtxMin(ctx, "[MinIO] Copying object",
+	c.logDebug(ctx, "[MinIO] Copying object",
 		slog.String("bucket", c.bucketName),
 		slog.String("src", fullSrcPath),
 		slog.String("dest", fullDestPath))
 
 	// Create source object options
 	srcOpts := minio.CopySrcOptions{
-		Bucket: c.bucketName,
-		Object: fullSrcPath,
+		Bucket:     c.bucketName,
+		Object:     fullSrcPath,
+		Encryption: c.readSSE(nil),
 	}
 
-	uploadInfo, err := c.minio.CopyObject(ctx, minio.CopyDestOptions{
-		Bucket: c.bucketName,
-		Object: fullDestPath,
-	}, srcOpts)
+	// Set the destination in the opts
+	opts.Bucket = c.bucketName
+	opts.Object = fullDestPath
+	opts.Encryption = c.writeSSE(opts.Encryption)
+
+	defer c.invalidateFullPath(fullDestPath)
+	uploadInfo, err = withRetry(ctx, c, "CopyObject", func() (minio.UploadInfo, error) {
+		return c.minio.CopyObject(ctx, opts, srcOpts)
+	})
 
 	if err != nil {
 		return uploadInfo, err
 	}
 
 	// Strip base path from returned upload info
-	uploadInfo.Key = c.stripBasePath(uploadInfo.Key)
+	uploadInfo.Key = c.stripBasePath(ctx, uploadInfo.Key)
 	return uploadInfo, nil
 }
+
+// CopyObjectTo copies an object from the configured bucket into another bucket with automatic path handling
+// The base directory prefix is applied to both the source and the destination path
+func (c *Client) CopyObjectTo(ctx context.Context, destBucket string, destObjectPath string, srcObjectPath string, opts minio.CopyDestOptions) (uploadInfo minio.UploadInfo, err error) {
+	ctx, span := c.startOperation(ctx, "CopyObjectTo",
+		slog.String("src", srcObjectPath),
+		slog.String("destBucket", destBucket),
+		slog.String("dest", destObjectPath))
+	defer func() { span.End(err) }()
+
+	if destBucket == "" {
+		return minio.UploadInfo{}, fmt.Errorf("destination bucket is required")
+	}
+	if err := c.validatePath(ctx, destObjectPath); err != nil {
+		return minio.UploadInfo{}, err
+	}
+	if err := c.validatePath(ctx, srcObjectPath); err != nil {
+		return minio.UploadInfo{}, err
+	}
+
+	fullDestPath := c.buildPath(ctx, destObjectPath)
+	fullSrcPath := c.buildPath(ctx, srcObjectPath)
+
+	c.logDebug(ctx, "[MinIO] Copying object to bucket",
+		slog.String("bucket", c.bucketName),
+		slog.String("src", fullSrcPath),
+		slog.String("destBucket", destBucket),
+		slog.String("dest", fullDestPath))
+
+	srcOpts := minio.CopySrcOptions{
+		Bucket:     c.bucketName,
+		Object:     fullSrcPath,
+		Encryption: c.readSSE(nil),
+	}
+
+	// Set the destination in the opts
+	opts.Bucket = destBucket
+	opts.Object = fullDestPath
+	opts.Encryption = c.writeSSE(opts.Encryption)
+
+	defer c.statCache.delete(destBucket + "/" + fullDestPath)
+	uploadInfo, err = withRetry(ctx, c, "CopyObjectTo", func() (minio.UploadInfo, error) {
+		return c.minio.CopyObject(ctx, opts, srcOpts)
+	})
+	if err != nil {
+		return uploadInfo, err
+	}
+
+	// Strip base path from returned upload info
+	uploadInfo.Key = c.stripBasePath(ctx, uploadInfo.Key)
+	return uploadInfo, nil
+}
+
+// putObjectWithRetry uploads an object, retrying transient errors only when the reader can be rewound
+func (c *Client) putObjectWithRetry(ctx context.Context, fullPath string, reader io.Reader, objectSize int64, opts minio.PutObjectOptions) (minio.UploadInfo, error) {
+	seeker, ok := reader.(io.Seeker)
+	if reader != nil && !ok {
+		return c.minio.PutObject(ctx, c.bucketName, fullPath, reader, objectSize, opts)
+	}
+
+	var offset int64
+	if seeker != nil {
+		var err error
+		if offset, err = seeker.Seek(0, io.SeekCurrent); err != nil {
+			return c.minio.PutObject(ctx, c.bucketName, fullPath, reader, objectSize, opts)
+		}
+	}
+
+	attempt := 0
+	return withRetry(ctx, c, "PutObject", func() (minio.UploadInfo, error) {
+		attempt++
+		if attempt > 1 && seeker != nil {
+			if _, err := seeker.Seek(offset, io.SeekStart); err != nil {
+				return minio.UploadInfo{}, err
+			}
+		}
+		return c.minio.PutObject(ctx, c.bucketName, fullPath, reader, objectSize, opts)
+	})
+}
+
+// sniffLength is the number of bytes inspected by http.DetectContentType
+const sniffLength = 512
+
+// detectContentType determines the content type of an upload, first by the object path extension
+// and then by sniffing the leading bytes of the reader. The returned reader must be used for the
+// upload: seekable readers are rewound, other readers are wrapped so the sniffed bytes are replayed.
+func detectContentType(objectPath string, reader io.Reader, objectSize int64) (string, io.Reader, error) {
+	if contentType := mime.TypeByExtension(path.Ext(objectPath)); contentType != "" {
+		return contentType, reader, nil
+	}
+
+	if reader == nil || objectSize == 0 {
+		return "application/octet-stream", reader, nil
+	}
+
+	bufSize := int64(sniffLength)
+	if objectSize > 0 && objectSize < bufSize {
+		bufSize = objectSize
+	}
+	buf := make([]byte, bufSize)
+
+	if seeker, ok := reader.(io.Seeker); ok {
+		offset, err := seeker.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return "", nil, err
+		}
+
+		n, err := io.ReadFull(reader, buf)
+		if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+			return "", nil, err
+		}
+
+		if _, err := seeker.Seek(offset, io.SeekStart); err != nil {
+			return "", nil, err
+		}
+		return http.DetectContentType(buf[:n]), reader, nil
+	}
+
+	n, err := io.ReadFull(reader, buf)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return "", nil, err
+	}
+
+	// Replay the sniffed bytes ahead of the remaining stream
+	return http.DetectContentType(buf[:n]), io.MultiReader(bytes.NewReader(buf[:n]), reader), nil
+}
@@ -0,0 +1,116 @@
+package miniox
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/minio/minio-go/v7"
+)
+
+// readerOnly hides every method but Read, so the reader is not an io.Seeker
+type readerOnly struct {
+	io.Reader
+}
+
+func TestDetectContentType(t *testing.T) {
+	png := append([]byte("\x89PNG\r\n\x1a\n"), make([]byte, 32)...)
+
+	tests := []struct {
+		name       string
+		objectPath string
+		content    []byte
+		want       string
+	}{
+		{"json extension", "data/export.json", []byte("not actually json"), "application/json"},
+		{"svg extension", "img/logo.svg", []byte("<svg></svg>"), "image/svg+xml"},
+		{"unknown extension sniffs png", "img/logo.unknownext", png, "image/png"},
+		{"no extension sniffs html", "pages/index", []byte("<!DOCTYPE html><html></html>"), "text/html; charset=utf-8"},
+		{"no extension sniffs text", "notes/readme", []byte("plain words"), "text/plain; charset=utf-8"},
+		{"empty object", "blob", nil, "application/octet-stream"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, reader := range []io.Reader{bytes.NewReader(tt.content), readerOnly{bytes.NewReader(tt.content)}} {
+				contentType, detected, err := detectContentType(tt.objectPath, reader, int64(len(tt.content)))
+				if err != nil {
+					t.Fatalf("detectContentType: %v", err)
+				}
+				if !strings.HasPrefix(contentType, tt.want) {
+					t.Errorf("%T: content type = %q, want %q", reader, contentType, tt.want)
+				}
+
+				// The detected reader must still yield the whole content
+				got, err := io.ReadAll(detected)
+				if err != nil {
+					t.Fatalf("read: %v", err)
+				}
+				if !bytes.Equal(got, tt.content) {
+					t.Errorf("%T: content = %q, want %q", reader, got, tt.content)
+				}
+			}
+		})
+	}
+}
+
+func TestDetectContentTypeRewindsSeeker(t *testing.T) {
+	content := []byte("header:<html><body>page</body></html>")
+	reader := bytes.NewReader(content)
+	if _, err := reader.Seek(7, io.SeekStart); err != nil {
+		t.Fatal(err)
+	}
+
+	contentType, detected, err := detectContentType("page", reader, int64(len(content)-7))
+	if err != nil {
+		t.Fatalf("detectContentType: %v", err)
+	}
+	if contentType != "text/html; charset=utf-8" {
+		t.Errorf("content type = %q, want text/html", contentType)
+	}
+	if detected != io.Reader(reader) {
+		t.Error("seekable reader was wrapped instead of rewound")
+	}
+	if got, _ := io.ReadAll(detected); string(got) != string(content[7:]) {
+		t.Errorf("content = %q, want %q", got, content[7:])
+	}
+}
+
+func TestPutObjectAutoDetectContentTypeNonSeeker(t *testing.T) {
+	ctx := context.Background()
+	c, fake := newFakeS3Client(t, "")
+	c.autoDetectContentType = true
+
+	// Larger than the sniffed prefix, so the replayed bytes and the rest of the stream must be joined
+	content := []byte("<!DOCTYPE html><html><body>" + strings.Repeat("x", 4096) + "</body></html>")
+	if _, err := c.PutObject(ctx, "pages/index", readerOnly{bytes.NewReader(content)}, int64(len(content)), minio.PutObjectOptions{}); err != nil {
+		t.Fatalf("PutObject: %v", err)
+	}
+
+	object := fake.object("pages/index")
+	if object == nil {
+		t.Fatal("object was not stored")
+	}
+	if got := object.header.Get("Content-Type"); got != "text/html; charset=utf-8" {
+		t.Errorf("Content-Type = %q, want text/html", got)
+	}
+	if !bytes.Equal(object.data, content) {
+		t.Errorf("stored %d bytes, want the %d uploaded bytes", len(object.data), len(content))
+	}
+}
+
+func TestPutObjectKeepsExplicitContentType(t *testing.T) {
+	ctx := context.Background()
+	c, fake := newFakeS3Client(t, "")
+	c.autoDetectContentType = true
+
+	content := []byte("{}")
+	if _, err := c.PutObject(ctx, "data.json", bytes.NewReader(content), int64(len(content)), minio.PutObjectOptions{ContentType: "text/plain"}); err != nil {
+		t.Fatalf("PutObject: %v", err)
+	}
+	if got := fake.object("data.json").header.Get("Content-Type"); got != "text/plain" {
+		t.Errorf("Content-Type = %q, want text/plain", got)
+	}
+}
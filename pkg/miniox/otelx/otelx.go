@@ -0,0 +1,99 @@
+// Package otelx adapts OpenTelemetry tracing to the miniox.Tracer interface.
+// It lives in its own package so users who don't need tracing don't have to import OpenTelemetry.
+package otelx
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/aeternitas-infinita/minio-go-extended/pkg/miniox"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// instrumentationName identifies spans created by this package
+const instrumentationName = "github.com/aeternitas-infinita/minio-go-extended/pkg/miniox"
+
+// tracer implements miniox.Tracer on top of an OpenTelemetry tracer
+type tracer struct {
+	tracer trace.Tracer
+}
+
+// span implements miniox.Span on top of an OpenTelemetry span
+type span struct {
+	span trace.Span
+}
+
+// NewTracer creates a miniox.Tracer from an OpenTelemetry TracerProvider
+// If provider is nil, the global TracerProvider is used.
+func NewTracer(provider trace.TracerProvider) miniox.Tracer {
+	if provider == nil {
+		provider = otel.GetTracerProvider()
+	}
+
+	return &tracer{tracer: provider.Tracer(instrumentationName)}
+}
+
+// FromTracer creates a miniox.Tracer from an existing OpenTelemetry Tracer
+// Returns nil for a nil tracer, which disables tracing when assigned to miniox.Config.Tracer.
+func FromTracer(otelTracer trace.Tracer) miniox.Tracer {
+	if otelTracer == nil {
+		return nil
+	}
+
+	return &tracer{tracer: otelTracer}
+}
+
+// Start begins a client span as a child of the span carried by ctx
+func (t *tracer) Start(ctx context.Context, spanName string, attrs ...slog.Attr) (context.Context, miniox.Span) {
+	ctx, otelSpan := t.tracer.Start(ctx, spanName,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(convertAttrs(attrs)...))
+
+	return ctx, &span{span: otelSpan}
+}
+
+// SetAttributes adds attributes to the span
+func (s *span) SetAttributes(attrs ...slog.Attr) {
+	s.span.SetAttributes(convertAttrs(attrs)...)
+}
+
+// End records the error status, if any, and finishes the span
+func (s *span) End(err error) {
+	if err != nil {
+		s.span.RecordError(err)
+		s.span.SetStatus(codes.Error, err.Error())
+	}
+	s.span.End()
+}
+
+// convertAttrs converts slog attributes into OpenTelemetry attributes with a "miniox." key prefix
+func convertAttrs(attrs []slog.Attr) []attribute.KeyValue {
+	result := make([]attribute.KeyValue, 0, len(attrs))
+	for _, attr := range attrs {
+		key := "miniox." + attr.Key
+		value := attr.Value.Resolve()
+
+		switch value.Kind() {
+		case slog.KindString:
+			result = append(result, attribute.String(key, value.String()))
+		case slog.KindInt64:
+			result = append(result, attribute.Int64(key, value.Int64()))
+		case slog.KindUint64:
+			result = append(result, attribute.Int64(key, int64(value.Uint64())))
+		case slog.KindFloat64:
+			result = append(result, attribute.Float64(key, value.Float64()))
+		case slog.KindBool:
+			result = append(result, attribute.Bool(key, value.Bool()))
+		case slog.KindDuration:
+			result = append(result, attribute.String(key, value.Duration().String()))
+		default:
+			result = append(result, attribute.String(key, fmt.Sprint(value.Any())))
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,319 @@
+package miniox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+)
+
+const (
+	// policyVersion is the S3 policy language version used for generated policies
+	policyVersion = "2012-10-17"
+
+	// s3GetObjectAction is the action granted by public read statements
+	s3GetObjectAction = "s3:GetObject"
+)
+
+// bucketPolicy represents an S3 bucket policy document
+// Statements are kept raw so unrelated statements round-trip unchanged
+type bucketPolicy struct {
+	Version   string            `json:"Version"`
+	ID        string            `json:"Id,omitempty"`
+	Statement []json.RawMessage `json:"Statement"`
+}
+
+// policyStatement is a lenient view of a single bucket policy statement
+type policyStatement struct {
+	Sid       string          `json:"Sid,omitempty"`
+	Effect    string          `json:"Effect"`
+	Principal any             `json:"Principal,omitempty"`
+	Action    stringOrSlice   `json:"Action,omitempty"`
+	Resource  stringOrSlice   `json:"Resource,omitempty"`
+	Condition json.RawMessage `json:"Condition,omitempty"`
+}
+
+// stringOrSlice decodes policy fields that may be either a single string or a list of strings
+type stringOrSlice []string
+
+// UnmarshalJSON implements json.Unmarshaler
+func (s *stringOrSlice) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*s = stringOrSlice{single}
+		return nil
+	}
+
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return err
+	}
+	*s = list
+	return nil
+}
+
+// SetPublicReadPolicy sets a bucket policy granting anonymous read access to objects under the prefix
+// The prefix is resolved with the base directory prefix, so an empty prefix exposes the whole base directory.
+// Note: this replaces any existing bucket policy; use AllowPublicRead to merge into it instead
+func (c *Client) SetPublicReadPolicy(ctx context.Context, prefix string) error {
+	if err := c.validatePath(ctx, prefix); err != nil {
+		return err
+	}
+
+	resource := c.publicReadResource(ctx, prefix)
+
+	statement, err := json.Marshal(newPublicReadStatement(resource))
+	if err != nil {
+		return fmt.Errorf("failed to marshal bucket policy: %w", err)
+	}
+
+	c.logDebug(ctx, "[MinIO] Setting public read policy",
+		slog.String("bucket", c.bucketName),
+		slog.String("resource", resource))
+
+	return c.saveBucketPolicy(ctx, &bucketPolicy{
+		Version:   policyVersion,
+		Statement: []json.RawMessage{statement},
+	})
+}
+
+// AllowPublicRead grants anonymous read access to objects under a relative prefix
+// The statement is merged into the current bucket policy without touching unrelated statements
+func (c *Client) AllowPublicRead(ctx context.Context, relativePrefix string) error {
+	if err := c.validatePath(ctx, relativePrefix); err != nil {
+		return err
+	}
+
+	resource := c.publicReadResource(ctx, relativePrefix)
+
+	policy, err := c.loadBucketPolicy(ctx)
+	if err != nil {
+		return err
+	}
+
+	changed, err := policy.addPublicRead(resource)
+	if err != nil || !changed {
+		return err
+	}
+
+	c.logDebug(ctx, "[MinIO] Allowing public read",
+		slog.String("bucket", c.bucketName),
+		slog.String("resource", resource))
+
+	return c.saveBucketPolicy(ctx, policy)
+}
+
+// RemovePublicRead revokes anonymous read access previously granted for a relative prefix
+// Only plain public-read statements are modified; the bucket policy is removed entirely if it becomes empty
+func (c *Client) RemovePublicRead(ctx context.Context, relativePrefix string) error {
+	if err := c.validatePath(ctx, relativePrefix); err != nil {
+		return err
+	}
+
+	resource := c.publicReadResource(ctx, relativePrefix)
+
+	policy, err := c.loadBucketPolicy(ctx)
+	if err != nil {
+		return err
+	}
+
+	changed, err := policy.removePublicRead(resource)
+	if err != nil || !changed {
+		return err
+	}
+
+	c.logDebug(ctx, "[MinIO] Removing public read",
+		slog.String("bucket", c.bucketName),
+		slog.String("resource", resource))
+
+	return c.saveBucketPolicy(ctx, policy)
+}
+
+// GetEffectivePublicPrefixes returns the relative prefixes that are publicly readable under the base directory prefix
+// An empty string in the result means the whole base directory (or bucket) is public
+func (c *Client) GetEffectivePublicPrefixes(ctx context.Context) ([]string, error) {
+	policy, err := c.loadBucketPolicy(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.publicPrefixes(ctx, policy), nil
+}
+
+// publicPrefixes returns the relative prefixes made publicly readable by the statements of a policy
+func (c *Client) publicPrefixes(ctx context.Context, policy *bucketPolicy) []string {
+	bucketARN := "arn:aws:s3:::" + c.bucketName + "/"
+	basePrefix := c.buildPath(ctx, "")
+
+	var prefixes []string
+	for _, raw := range policy.Statement {
+		var statement policyStatement
+		if err := json.Unmarshal(raw, &statement); err != nil || !statement.grantsPublicRead() {
+			continue
+		}
+
+		for _, resource := range statement.Resource {
+			if !strings.HasPrefix(resource, bucketARN) || !strings.HasSuffix(resource, "*") {
+				continue
+			}
+
+			fullPrefix := strings.TrimSuffix(strings.TrimSuffix(strings.TrimPrefix(resource, bucketARN), "*"), "/")
+
+			var relativePrefix string
+			switch {
+			case fullPrefix == "" || fullPrefix == basePrefix || strings.HasPrefix(basePrefix, fullPrefix+"/"):
+				// The resource covers the whole base directory
+				relativePrefix = ""
+			case basePrefix == "":
+				relativePrefix = fullPrefix
+			case strings.HasPrefix(fullPrefix, basePrefix+"/"):
+				relativePrefix = c.stripBasePath(ctx, fullPrefix)
+			default:
+				continue
+			}
+
+			if !slices.Contains(prefixes, relativePrefix) {
+				prefixes = append(prefixes, relativePrefix)
+			}
+		}
+	}
+
+	slices.Sort(prefixes)
+	return prefixes
+}
+
+// publicReadResource returns the object ARN pattern for a relative prefix
+func (c *Client) publicReadResource(ctx context.Context, relativePrefix string) string {
+	return "arn:aws:s3:::" + c.bucketName + "/" + c.buildFolderPath(ctx, relativePrefix) + "*"
+}
+
+// loadBucketPolicy gets and parses the bucket policy, returning an empty policy if none is set
+func (c *Client) loadBucketPolicy(ctx context.Context) (*bucketPolicy, error) {
+	policyJSON, err := c.GetBucketPolicy(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	policy := &bucketPolicy{Version: policyVersion}
+	if policyJSON == "" {
+		return policy, nil
+	}
+
+	if err := json.Unmarshal([]byte(policyJSON), policy); err != nil {
+		return nil, fmt.Errorf("failed to parse bucket policy: %w", err)
+	}
+	return policy, nil
+}
+
+// addPublicRead appends a public read statement for the resource unless one already grants it
+// Other statements are kept unchanged; reports whether the policy was modified
+func (p *bucketPolicy) addPublicRead(resource string) (bool, error) {
+	for _, raw := range p.Statement {
+		var statement policyStatement
+		if err := json.Unmarshal(raw, &statement); err != nil {
+			continue
+		}
+		if statement.grantsPublicRead() && slices.Contains(statement.Resource, resource) {
+			return false, nil // Already public
+		}
+	}
+
+	statement, err := json.Marshal(newPublicReadStatement(resource))
+	if err != nil {
+		return false, fmt.Errorf("failed to marshal policy statement: %w", err)
+	}
+	p.Statement = append(p.Statement, statement)
+	return true, nil
+}
+
+// removePublicRead removes the resource from plain public read statements, dropping statements left empty
+// Other statements are kept unchanged; reports whether the policy was modified
+func (p *bucketPolicy) removePublicRead(resource string) (bool, error) {
+	changed := false
+	statements := make([]json.RawMessage, 0, len(p.Statement))
+	for _, raw := range p.Statement {
+		var statement policyStatement
+		if err := json.Unmarshal(raw, &statement); err != nil ||
+			!statement.isPlainPublicRead() || !slices.Contains(statement.Resource, resource) {
+			statements = append(statements, raw)
+			continue
+		}
+
+		changed = true
+		statement.Resource = slices.DeleteFunc(statement.Resource, func(r string) bool { return r == resource })
+		if len(statement.Resource) == 0 {
+			continue
+		}
+
+		updated, err := json.Marshal(statement)
+		if err != nil {
+			return false, fmt.Errorf("failed to marshal policy statement: %w", err)
+		}
+		statements = append(statements, updated)
+	}
+
+	if changed {
+		p.Statement = statements
+	}
+	return changed, nil
+}
+
+// saveBucketPolicy writes the bucket policy, removing it when it has no statements
+func (c *Client) saveBucketPolicy(ctx context.Context, policy *bucketPolicy) error {
+	if len(policy.Statement) == 0 {
+		return c.SetBucketPolicy(ctx, "")
+	}
+
+	policyJSON, err := json.Marshal(policy)
+	if err != nil {
+		return fmt.Errorf("failed to marshal bucket policy: %w", err)
+	}
+	return c.SetBucketPolicy(ctx, string(policyJSON))
+}
+
+// newPublicReadStatement creates a statement granting anonymous s3:GetObject on the resource
+func newPublicReadStatement(resource string) policyStatement {
+	return policyStatement{
+		Effect:    "Allow",
+		Principal: map[string][]string{"AWS": {"*"}},
+		Action:    stringOrSlice{s3GetObjectAction},
+		Resource:  stringOrSlice{resource},
+	}
+}
+
+// grantsPublicRead reports whether the statement unconditionally allows anyone to read objects
+func (s policyStatement) grantsPublicRead() bool {
+	if s.Effect != "Allow" || len(s.Condition) > 0 || !isPublicPrincipal(s.Principal) {
+		return false
+	}
+	return slices.ContainsFunc(s.Action, func(action string) bool {
+		return action == s3GetObjectAction || action == "s3:*" || action == "*"
+	})
+}
+
+// isPlainPublicRead reports whether the statement only grants public s3:GetObject, as generated by this package
+func (s policyStatement) isPlainPublicRead() bool {
+	return s.grantsPublicRead() && len(s.Action) == 1 && s.Action[0] == s3GetObjectAction
+}
+
+// isPublicPrincipal reports whether a policy principal matches anonymous users
+func isPublicPrincipal(principal any) bool {
+	switch p := principal.(type) {
+	case string:
+		return p == "*"
+	case map[string]any:
+		switch aws := p["AWS"].(type) {
+		case string:
+			return aws == "*"
+		case []any:
+			for _, value := range aws {
+				if value == "*" {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,170 @@
+package miniox
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"testing"
+)
+
+// unrelatedStatement is a statement that merging public read access must never modify
+const unrelatedStatement = `{"Sid":"Backup","Effect":"Allow","Principal":{"AWS":["arn:aws:iam::123456789012:role/backup"]},"Action":["s3:GetObject","s3:PutObject"],"Resource":["arn:aws:s3:::test-bucket/*"]}`
+
+func parsePolicy(t *testing.T, policyJSON string) *bucketPolicy {
+	t.Helper()
+
+	policy := &bucketPolicy{}
+	if err := json.Unmarshal([]byte(policyJSON), policy); err != nil {
+		t.Fatalf("failed to parse policy: %v", err)
+	}
+	return policy
+}
+
+func TestIsPublicPrincipal(t *testing.T) {
+	tests := []struct {
+		principal string
+		want      bool
+	}{
+		{`"*"`, true},
+		{`{"AWS":"*"}`, true},
+		{`{"AWS":["*"]}`, true},
+		{`{"AWS":["arn:aws:iam::123456789012:root","*"]}`, true},
+		{`{"AWS":["arn:aws:iam::123456789012:root"]}`, false},
+		{`{"Service":"*"}`, false},
+		{`"arn:aws:iam::123456789012:root"`, false},
+		{`null`, false},
+	}
+
+	for _, tt := range tests {
+		var principal any
+		if err := json.Unmarshal([]byte(tt.principal), &principal); err != nil {
+			t.Fatalf("failed to parse principal %s: %v", tt.principal, err)
+		}
+		if got := isPublicPrincipal(principal); got != tt.want {
+			t.Errorf("isPublicPrincipal(%s) = %v, want %v", tt.principal, got, tt.want)
+		}
+	}
+}
+
+func TestGrantsPublicRead(t *testing.T) {
+	tests := []struct {
+		name      string
+		statement string
+		want      bool
+		wantPlain bool
+	}{
+		{"generated", `{"Effect":"Allow","Principal":{"AWS":["*"]},"Action":["s3:GetObject"],"Resource":["arn:aws:s3:::b/*"]}`, true, true},
+		{"single action string", `{"Effect":"Allow","Principal":"*","Action":"s3:GetObject","Resource":"arn:aws:s3:::b/*"}`, true, true},
+		{"wildcard action", `{"Effect":"Allow","Principal":"*","Action":"s3:*","Resource":"arn:aws:s3:::b/*"}`, true, false},
+		{"deny", `{"Effect":"Deny","Principal":"*","Action":"s3:GetObject","Resource":"arn:aws:s3:::b/*"}`, false, false},
+		{"conditional", `{"Effect":"Allow","Principal":"*","Action":"s3:GetObject","Resource":"arn:aws:s3:::b/*","Condition":{"IpAddress":{"aws:SourceIp":"10.0.0.0/8"}}}`, false, false},
+		{"named principal", unrelatedStatement, false, false},
+		{"other action", `{"Effect":"Allow","Principal":"*","Action":"s3:ListBucket","Resource":"arn:aws:s3:::b"}`, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var statement policyStatement
+			if err := json.Unmarshal([]byte(tt.statement), &statement); err != nil {
+				t.Fatalf("failed to parse statement: %v", err)
+			}
+			if got := statement.grantsPublicRead(); got != tt.want {
+				t.Errorf("grantsPublicRead() = %v, want %v", got, tt.want)
+			}
+			if got := statement.isPlainPublicRead(); got != tt.wantPlain {
+				t.Errorf("isPlainPublicRead() = %v, want %v", got, tt.wantPlain)
+			}
+		})
+	}
+}
+
+func TestBucketPolicyAddRemovePublicRead(t *testing.T) {
+	ctx := context.Background()
+	c := newTestClient(t, "app-data")
+	resource := c.publicReadResource(ctx, "images")
+
+	policy := parsePolicy(t, `{"Version":"2012-10-17","Statement":[`+unrelatedStatement+`]}`)
+
+	changed, err := policy.addPublicRead(resource)
+	if err != nil || !changed {
+		t.Fatalf("addPublicRead = %v, %v, want true, nil", changed, err)
+	}
+	if len(policy.Statement) != 2 || string(policy.Statement[0]) != unrelatedStatement {
+		t.Fatalf("unrelated statement was modified: %s", policy.Statement)
+	}
+
+	changed, err = policy.addPublicRead(resource)
+	if err != nil || changed {
+		t.Errorf("second addPublicRead = %v, %v, want false, nil", changed, err)
+	}
+	if got := c.publicPrefixes(ctx, policy); !slices.Equal(got, []string{"images"}) {
+		t.Errorf("publicPrefixes = %q, want [images]", got)
+	}
+
+	changed, err = policy.removePublicRead(c.publicReadResource(ctx, "videos"))
+	if err != nil || changed {
+		t.Errorf("removePublicRead(videos) = %v, %v, want false, nil", changed, err)
+	}
+
+	changed, err = policy.removePublicRead(resource)
+	if err != nil || !changed {
+		t.Fatalf("removePublicRead = %v, %v, want true, nil", changed, err)
+	}
+	if len(policy.Statement) != 1 || string(policy.Statement[0]) != unrelatedStatement {
+		t.Errorf("policy after removal = %s, want only the unrelated statement", policy.Statement)
+	}
+}
+
+func TestBucketPolicyRemovePublicReadKeepsOtherResources(t *testing.T) {
+	ctx := context.Background()
+	c := newTestClient(t, "")
+
+	policy := parsePolicy(t, `{"Version":"2012-10-17","Statement":[
+		{"Effect":"Allow","Principal":"*","Action":"s3:GetObject","Resource":["arn:aws:s3:::test-bucket/images/*","arn:aws:s3:::test-bucket/videos/*"]},
+		{"Effect":"Allow","Principal":"*","Action":["s3:GetObject","s3:ListBucket"],"Resource":["arn:aws:s3:::test-bucket/images/*"]}
+	]}`)
+
+	changed, err := policy.removePublicRead(c.publicReadResource(ctx, "images"))
+	if err != nil || !changed {
+		t.Fatalf("removePublicRead = %v, %v, want true, nil", changed, err)
+	}
+
+	// The second statement grants more than public read and is left alone
+	if got := c.publicPrefixes(ctx, policy); !slices.Equal(got, []string{"images", "videos"}) {
+		t.Errorf("publicPrefixes = %q, want [images videos]", got)
+	}
+	if len(policy.Statement) != 2 {
+		t.Errorf("len(Statement) = %d, want 2", len(policy.Statement))
+	}
+}
+
+func TestPublicPrefixes(t *testing.T) {
+	ctx := context.Background()
+	policyJSON := `{"Version":"2012-10-17","Statement":[
+		{"Effect":"Allow","Principal":"*","Action":"s3:GetObject","Resource":["arn:aws:s3:::test-bucket/app-data/images/*","arn:aws:s3:::test-bucket/other/*"]},
+		{"Effect":"Allow","Principal":"*","Action":"s3:GetObject","Resource":"arn:aws:s3:::other-bucket/app-data/docs/*"},
+		{"Effect":"Deny","Principal":"*","Action":"s3:GetObject","Resource":"arn:aws:s3:::test-bucket/app-data/private/*"},
+		` + unrelatedStatement + `
+	]}`
+
+	tests := []struct {
+		name          string
+		baseDirPrefix string
+		policy        string
+		want          []string
+	}{
+		{"with base prefix", "app-data", policyJSON, []string{"images"}},
+		{"without base prefix", "", policyJSON, []string{"app-data/images", "other"}},
+		{"whole bucket public", "app-data", `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":"*","Action":"s3:GetObject","Resource":"arn:aws:s3:::test-bucket/*"}]}`, []string{""}},
+		{"no statements", "app-data", `{"Version":"2012-10-17","Statement":[]}`, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t, tt.baseDirPrefix)
+			if got := c.publicPrefixes(ctx, parsePolicy(t, tt.policy)); !slices.Equal(got, tt.want) {
+				t.Errorf("publicPrefixes = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package miniox
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+)
+
+const (
+	// writeCheckPrefix is the reserved folder holding CheckWritable probe objects
+	writeCheckPrefix = ".miniox-writecheck"
+
+	// writeCheckCleanupTimeout bounds the removal of a probe object once ctx is done
+	writeCheckCleanupTimeout = 10 * time.Second
+)
+
+// ErrNotWritable is returned by CheckWritable when the credentials may not write to the bucket
+type ErrNotWritable struct {
+	Bucket    string // Bucket name
+	Operation string // Denied operation, "PutObject" or "RemoveObject"
+	Err       error  // Underlying server error
+}
+
+// Error implements the error interface
+func (e *ErrNotWritable) Error() string {
+	return fmt.Sprintf("bucket %s is not writable: %s denied: %v", e.Bucket, e.Operation, e.Err)
+}
+
+// Unwrap returns the underlying server error
+func (e *ErrNotWritable) Unwrap() error {
+	return e.Err
+}
+
+// CheckWritable verifies that the client can write to its bucket by uploading and removing a tiny probe object
+// The probe is stored under ".miniox-writecheck/<uuid>" below the base directory prefix and is removed even when
+// the upload fails or ctx is cancelled, since a failed request may still have stored it. Returns *ErrNotWritable
+// when the upload or removal is denied
+func (c *Client) CheckWritable(ctx context.Context) (err error) {
+	ctx, span := c.startOperation(ctx, "CheckWritable")
+	defer func() { span.End(err) }()
+
+	fullPath := c.buildPath(ctx, writeCheckPrefix+"/"+newUUID())
+
+	c.logDebug(ctx, "[MinIO] Checking bucket is writable",
+		slog.String("bucket", c.bucketName),
+		slog.String("object", fullPath))
+
+	probe := []byte("miniox write check")
+	_, putErr := c.minio.PutObject(ctx, c.bucketName, fullPath, bytes.NewReader(probe), int64(len(probe)), minio.PutObjectOptions{
+		ContentType:          "text/plain",
+		ServerSideEncryption: c.writeSSE(nil),
+	})
+
+	cleanupCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), writeCheckCleanupTimeout)
+	defer cancel()
+	removeErr := c.minio.RemoveObject(cleanupCtx, c.bucketName, fullPath, minio.RemoveObjectOptions{})
+
+	switch {
+	case putErr != nil && isAccessDenied(putErr):
+		return &ErrNotWritable{Bucket: c.bucketName, Operation: "PutObject", Err: putErr}
+	case putErr != nil:
+		return fmt.Errorf("failed to upload write check object: %w", putErr)
+	case removeErr != nil && isAccessDenied(removeErr):
+		return &ErrNotWritable{Bucket: c.bucketName, Operation: "RemoveObject", Err: removeErr}
+	case removeErr != nil:
+		return fmt.Errorf("failed to remove write check object %s: %w", c.stripBasePath(ctx, fullPath), removeErr)
+	}
+	return nil
+}
+
+// isAccessDenied reports whether the server rejected a request for lack of permission
+func isAccessDenied(err error) bool {
+	return minio.ToErrorResponse(err).Code == "AccessDenied"
+}
@@ -0,0 +1,102 @@
+package miniox
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+
+	"github.com/minio/minio-go/v7"
+)
+
+// ErrInvalidRange is returned when a requested byte range lies outside the object
+type ErrInvalidRange struct {
+	ObjectPath string // Relative object path
+	Offset     int64  // Requested offset (negative for a suffix range)
+	Length     int64  // Requested length (0 means to the end of the object)
+	Size       int64  // Object size in bytes
+}
+
+// Error implements the error interface
+func (e *ErrInvalidRange) Error() string {
+	return fmt.Sprintf("invalid range for %s: offset %d, length %d, object size %d", e.ObjectPath, e.Offset, e.Length, e.Size)
+}
+
+// GetObjectRange opens a byte range of an object for reading
+// A non-negative offset reads length bytes from offset, or up to the end of the object when length is 0; ranges
+// past the end are truncated like S3 does. A negative offset reads the last -offset bytes (S3 "bytes=-N") and
+// requires length 0. The returned info describes the whole object, so info.Size is the total size.
+// Returns *ErrInvalidRange when offset is beyond the end of the object. The caller must close the returned reader
+func (c *Client) GetObjectRange(ctx context.Context, objectPath string, offset, length int64) (io.ReadCloser, minio.ObjectInfo, error) {
+	if length < 0 {
+		return nil, minio.ObjectInfo{}, fmt.Errorf("range length cannot be negative: %d", length)
+	}
+	if offset < 0 && length != 0 {
+		return nil, minio.ObjectInfo{}, fmt.Errorf("suffix ranges cannot have a length")
+	}
+
+	// A cached stat may be stale, so retry once with a fresh one if the object changed
+	for attempt := 1; ; attempt++ {
+		info, err := c.StatObject(ctx, objectPath, minio.StatObjectOptions{})
+		if err != nil {
+			return nil, minio.ObjectInfo{}, err
+		}
+
+		start, end, ok := resolveRange(offset, length, info.Size)
+		if !ok {
+			return nil, info, &ErrInvalidRange{ObjectPath: objectPath, Offset: offset, Length: length, Size: info.Size}
+		}
+		if start > end {
+			// Empty window of an empty object
+			return io.NopCloser(bytes.NewReader(nil)), info, nil
+		}
+
+		c.logDebug(ctx, "[MinIO] Getting object range",
+			slog.String("bucket", c.bucketName),
+			slog.String("object", objectPath),
+			slog.Int64("start", start),
+			slog.Int64("end", end))
+
+		opts := minio.GetObjectOptions{}
+		if err := opts.SetRange(start, end); err != nil {
+			return nil, info, err
+		}
+		if err := opts.SetMatchETag(info.ETag); err != nil {
+			return nil, info, err
+		}
+
+		object, _, err := c.OpenObject(ctx, objectPath, opts)
+		if err == nil {
+			return object, info, nil
+		}
+
+		switch {
+		case isPreconditionFailed(err) && attempt == 1:
+			c.InvalidateCache(ctx, objectPath)
+		case minio.ToErrorResponse(err).Code == "InvalidRange":
+			return nil, info, &ErrInvalidRange{ObjectPath: objectPath, Offset: offset, Length: length, Size: info.Size}
+		default:
+			return nil, info, err
+		}
+	}
+}
+
+// resolveRange converts a requested range into inclusive absolute bounds for an object of the given size
+// start > end denotes an empty window; ok is false when the range lies outside the object
+func resolveRange(offset, length, size int64) (start, end int64, ok bool) {
+	if offset < 0 {
+		return max(size+offset, 0), size - 1, true
+	}
+
+	if offset >= size {
+		// Reading an empty object from the start is an empty window rather than an error
+		return 0, -1, offset == 0 && size == 0
+	}
+
+	end = size - 1
+	if length > 0 {
+		end = min(offset+length-1, end)
+	}
+	return offset, end, true
+}
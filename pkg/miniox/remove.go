@@ -0,0 +1,201 @@
+package miniox
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+)
+
+// removeBatchSize matches the maximum number of keys per S3 DeleteObjects request
+const removeBatchSize = 1000
+
+// RemoveOptions configures bulk removal operations
+type RemoveOptions struct {
+	DryRun          bool // Only report matching objects without deleting them
+	Concurrency     int  // Number of delete batches processed in parallel (default 1)
+	ContinueOnError bool // Keep deleting after a failure and collect per-key errors
+}
+
+// RemoveResult reports the outcome of a bulk removal operation
+// All keys are relative to the base directory prefix
+type RemoveResult struct {
+	Matched []string         // Keys selected for removal
+	Deleted []string         // Keys actually removed (empty on dry run)
+	Skipped int              // Number of listed objects not selected for removal
+	Errors  map[string]error // Per-key removal errors
+
+	BytesReclaimed int64 // Total size of removed objects (of matched objects on dry run)
+}
+
+// RemoveObjectsByPrefix removes objects under a prefix that match the filter with automatic path prefix handling
+// The filter receives ObjectInfo with keys relative to the base directory prefix; a nil filter matches everything
+func (c *Client) RemoveObjectsByPrefix(ctx context.Context, prefix string, filter func(minio.ObjectInfo) bool, opts RemoveOptions) (result RemoveResult, err error) {
+	ctx, span := c.startOperation(ctx, "RemoveObjectsByPrefix",
+		slog.String("prefix", prefix),
+		slog.Bool("dryRun", opts.DryRun))
+	defer func() { span.End(err) }()
+
+	if err := c.validatePath(ctx, prefix); err != nil {
+		return RemoveResult{}, err
+	}
+
+	fullPrefix := c.buildPrefix(ctx, prefix)
+
+	c.logDebug(ctx, "[MinIO] Removing objects by prefix",
+		slog.String("bucket", c.bucketName),
+		slog.String("prefix", fullPrefix),
+		slog.Bool("dryRun", opts.DryRun))
+
+	listOpts := minio.ListObjectsOptions{
+		Prefix:    fullPrefix,
+		Recursive: true,
+	}
+
+	var matched []minio.ObjectInfo
+	for objectInfo := range c.minio.ListObjects(ctx, c.bucketName, listOpts) {
+		if objectInfo.Err != nil {
+			return result, objectInfo.Err
+		}
+
+		relativeInfo := objectInfo
+		relativeInfo.Key = c.stripBasePath(ctx, objectInfo.Key)
+		if filter != nil && !filter(relativeInfo) {
+			result.Skipped++
+			continue
+		}
+
+		matched = append(matched, objectInfo)
+		result.Matched = append(result.Matched, relativeInfo.Key)
+	}
+
+	if opts.DryRun {
+		for _, objectInfo := range matched {
+			result.BytesReclaimed += objectInfo.Size
+		}
+		return result, nil
+	}
+
+	if len(matched) == 0 {
+		return result, nil
+	}
+
+	return c.removeObjectBatches(ctx, matched, opts, result)
+}
+
+// RemoveObjectsOlderThan removes objects under a prefix last modified more than olderThan ago
+// Folder markers are never removed, and objects modified exactly at the cutoff are kept
+func (c *Client) RemoveObjectsOlderThan(ctx context.Context, prefix string, olderThan time.Duration, opts RemoveOptions) (RemoveResult, error) {
+	return c.RemoveObjectsModifiedBefore(ctx, prefix, time.Now().Add(-olderThan), opts)
+}
+
+// RemoveObjectsModifiedBefore removes objects under a prefix last modified strictly before the cutoff
+// Folder markers are never removed
+func (c *Client) RemoveObjectsModifiedBefore(ctx context.Context, prefix string, cutoff time.Time, opts RemoveOptions) (RemoveResult, error) {
+	c.logDebug(ctx, "[MinIO] Removing objects modified before cutoff",
+		slog.String("bucket", c.bucketName),
+		slog.String("prefix", prefix),
+		slog.Time("cutoff", cutoff))
+
+	return c.RemoveObjectsByPrefix(ctx, prefix, func(objectInfo minio.ObjectInfo) bool {
+		return !isFolderMarker(objectInfo.Key) && objectInfo.LastModified.Before(cutoff)
+	}, opts)
+}
+
+// removeObjectBatches deletes the given objects (full keys) in batches and records the outcome in result
+func (c *Client) removeObjectBatches(ctx context.Context, objects []minio.ObjectInfo, opts RemoveOptions, result RemoveResult) (RemoveResult, error) {
+	concurrency := opts.Concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	batches := make(chan []minio.ObjectInfo)
+	go func() {
+		defer close(batches)
+		for start := 0; start < len(objects); start += removeBatchSize {
+			end := min(start+removeBatchSize, len(objects))
+			select {
+			case batches <- objects[start:end]:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		firstErr error
+	)
+
+	for range concurrency {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for batch := range batches {
+				// Batches still queued after a fatal error are left untouched
+				if ctx.Err() != nil {
+					continue
+				}
+				failed := c.removeObjectBatch(ctx, batch)
+
+				mu.Lock()
+				for _, objectInfo := range batch {
+					key := c.stripBasePath(ctx, objectInfo.Key)
+					if removeErr, ok := failed[objectInfo.Key]; ok {
+						if result.Errors == nil {
+							result.Errors = make(map[string]error)
+						}
+						result.Errors[key] = removeErr
+						if firstErr == nil {
+							firstErr = removeErr
+						}
+						continue
+					}
+					result.Deleted = append(result.Deleted, key)
+					result.BytesReclaimed += objectInfo.Size
+				}
+				if firstErr != nil && !opts.ContinueOnError {
+					cancel()
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if !opts.ContinueOnError && firstErr != nil {
+		return result, firstErr
+	}
+
+	return result, nil
+}
+
+// removeObjectBatch deletes a single batch of objects and returns errors keyed by full object key
+func (c *Client) removeObjectBatch(ctx context.Context, batch []minio.ObjectInfo) map[string]error {
+	objectCh := make(chan minio.ObjectInfo, len(batch))
+	for _, objectInfo := range batch {
+		objectCh <- objectInfo
+	}
+	close(objectCh)
+
+	defer func() {
+		for _, objectInfo := range batch {
+			c.invalidateFullPath(objectInfo.Key)
+		}
+	}()
+
+	failed := make(map[string]error)
+	for removeErr := range c.minio.RemoveObjects(ctx, c.bucketName, objectCh, minio.RemoveObjectsOptions{}) {
+		if removeErr.Err != nil {
+			failed[removeErr.ObjectName] = removeErr.Err
+		}
+	}
+
+	return failed
+}
@@ -0,0 +1,136 @@
+package miniox
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+)
+
+const (
+	// defaultRetryInitialBackoff is the delay before the first retry when none is configured
+	defaultRetryInitialBackoff = 100 * time.Millisecond
+
+	// defaultRetryMaxBackoff is the maximum delay between retries when none is configured
+	defaultRetryMaxBackoff = 5 * time.Second
+)
+
+// defaultRetryableCodes are the S3 error codes considered transient when none are configured
+var defaultRetryableCodes = []string{
+	"SlowDown",
+	"SlowDownRead",
+	"SlowDownWrite",
+	"ServiceUnavailable",
+	"InternalError",
+	"RequestTimeout",
+	"Throttling",
+	"XMinioServerNotInitialized",
+}
+
+// RetryConfig configures retries of transient errors for idempotent operations
+type RetryConfig struct {
+	MaxAttempts    int           // Total attempts including the first one; retries are disabled when <= 1
+	InitialBackoff time.Duration // Delay before the first retry, doubled on each attempt (default 100ms)
+	MaxBackoff     time.Duration // Upper bound for the delay between attempts (default 5s)
+	RetryableCodes []string      // S3 error codes to retry (default: SlowDown, ServiceUnavailable, InternalError, ...)
+}
+
+// normalizeRetryConfig fills in defaults for unset retry settings
+func normalizeRetryConfig(config RetryConfig) RetryConfig {
+	if config.InitialBackoff <= 0 {
+		config.InitialBackoff = defaultRetryInitialBackoff
+	}
+	if config.MaxBackoff <= 0 {
+		config.MaxBackoff = defaultRetryMaxBackoff
+	}
+	if config.RetryableCodes == nil {
+		config.RetryableCodes = defaultRetryableCodes
+	}
+	return config
+}
+
+// withRetry runs fn and retries it on transient errors according to the retry configuration in effect for ctx
+// fn must be safe to repeat; context cancellation is honored between attempts
+func withRetry[T any](ctx context.Context, c *Client, operation string, fn func() (T, error)) (T, error) {
+	retry := c.retryConfig(ctx)
+	result, err := fn()
+
+	for attempt := 2; err != nil && attempt <= retry.MaxAttempts && retry.isRetryable(err); attempt++ {
+		backoff := retry.backoff(attempt - 1)
+
+		c.logDebug(ctx, "[MinIO] Retrying operation",
+			slog.String("operation", operation),
+			slog.Int("attempt", attempt),
+			slog.Int("maxAttempts", retry.MaxAttempts),
+			slog.Duration("backoff", backoff),
+			slog.String("error", err.Error()))
+
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return result, err
+		}
+
+		result, err = fn()
+	}
+
+	return result, err
+}
+
+// retryConfig returns the client retry configuration with any per-call options from ctx applied
+func (c *Client) retryConfig(ctx context.Context) RetryConfig {
+	callOpts, ok := ctx.Value(callOptionsKey{}).([]CallOption)
+	if !ok {
+		return c.retry
+	}
+
+	retry := c.retry
+	retry.RetryableCodes = slices.Clone(retry.RetryableCodes)
+	for _, opt := range callOpts {
+		opt(&retry)
+	}
+	return normalizeRetryConfig(retry)
+}
+
+// retryBackoff returns the jittered delay before the given retry (1-based) using the client configuration
+func (c *Client) retryBackoff(retry int) time.Duration {
+	return c.retry.backoff(retry)
+}
+
+// backoff returns the jittered delay before the given retry (1-based)
+func (r RetryConfig) backoff(retry int) time.Duration {
+	backoff := r.InitialBackoff
+	for i := 1; i < retry && backoff < r.MaxBackoff; i++ {
+		backoff *= 2
+	}
+	backoff = min(backoff, r.MaxBackoff)
+
+	// Equal jitter: between half and the full backoff
+	half := backoff / 2
+	return half + rand.N(half+1)
+}
+
+// isRetryable reports whether an error is transient and the operation may be repeated
+func (r RetryConfig) isRetryable(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	errResponse := minio.ToErrorResponse(err)
+	if errResponse.Code != "" {
+		return slices.Contains(r.RetryableCodes, errResponse.Code)
+	}
+	if errResponse.StatusCode >= http.StatusInternalServerError {
+		return true
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr) || errors.Is(err, io.ErrUnexpectedEOF)
+}
@@ -0,0 +1,693 @@
+package miniox
+
+import (
+	"bytes"
+	"crypto/md5"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+// fakeS3 is an in-memory S3 server implementing the subset of the API used by the client tests:
+// object GET/HEAD/PUT/DELETE with preconditions and SSE-C key checks, server-side copy, multipart uploads,
+// multi-object delete, ListObjectsV2, ListObjectVersions and the versioning, object lock and lifecycle bucket configurations
+type fakeS3 struct {
+	bucket string
+
+	mu         sync.Mutex
+	versioned  bool
+	objects    map[string][]*fakeObject // Versions of each key, latest last
+	uploads    map[string]*fakeUpload
+	lockConfig []byte
+	lifecycle  []byte
+	nextID     int
+	requests   []string // "METHOD key" of every object request
+
+	// beforeGet, when set, is called before an object GET is answered (outside the lock)
+	beforeGet func(key string)
+}
+
+// fakeObject is a single stored object version
+type fakeObject struct {
+	data         []byte
+	etag         string
+	versionID    string
+	header       http.Header
+	modTime      time.Time
+	deleteMarker bool
+}
+
+// fakeUpload is an in-progress multipart upload
+type fakeUpload struct {
+	key    string
+	header http.Header
+	parts  map[int][]byte
+}
+
+// storedHeaders are the request headers kept with an object and returned on GET and HEAD
+var storedHeaders = []string{
+	"Content-Type",
+	"Content-Encoding",
+	"Content-Disposition",
+	"Cache-Control",
+	"X-Amz-Storage-Class",
+	"X-Amz-Server-Side-Encryption-Customer-Algorithm",
+	"X-Amz-Server-Side-Encryption-Customer-Key-Md5",
+}
+
+// newFakeS3Client starts a fake S3 server and returns a client for its bucket
+func newFakeS3Client(t *testing.T, baseDirPrefix string) (*Client, *fakeS3) {
+	t.Helper()
+
+	fake := &fakeS3{
+		bucket:  "test-bucket",
+		objects: make(map[string][]*fakeObject),
+		uploads: make(map[string]*fakeUpload),
+	}
+	server := httptest.NewTLSServer(fake)
+	t.Cleanup(server.Close)
+
+	// Like minio.DefaultTransport, leave Content-Encoding alone instead of decompressing gzip responses
+	transport := server.Client().Transport.(*http.Transport).Clone()
+	transport.DisableCompression = true
+
+	mc, err := minio.New(strings.TrimPrefix(server.URL, "https://"), &minio.Options{
+		Creds:     credentials.NewStaticV4("access", "secret", ""),
+		Secure:    true,
+		Region:    "us-east-1",
+		Transport: transport,
+	})
+	if err != nil {
+		t.Fatalf("minio.New: %v", err)
+	}
+
+	return &Client{
+		minio:         mc,
+		bucketName:    fake.bucket,
+		baseDirPrefix: baseDirPrefix,
+		retry:         normalizeRetryConfig(RetryConfig{}),
+	}, fake
+}
+
+// put stores an object directly, bypassing the client
+func (f *fakeS3) put(key string, data []byte, header http.Header) *fakeObject {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.store(key, data, header, fmt.Sprintf("%x", md5.Sum(data)))
+}
+
+// object returns the latest version of a key, or nil when it does not exist or is deleted
+func (f *fakeS3) object(key string) *fakeObject {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.latest(key)
+}
+
+// requestCount returns how many object requests with the given method were made for a key
+func (f *fakeS3) requestCount(method, key string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	count := 0
+	for _, request := range f.requests {
+		if request == method+" "+key {
+			count++
+		}
+	}
+	return count
+}
+
+// store adds a new version of a key; callers must hold f.mu
+func (f *fakeS3) store(key string, data []byte, header http.Header, etag string) *fakeObject {
+	f.nextID++
+	object := &fakeObject{
+		data:    data,
+		etag:    etag,
+		header:  header,
+		modTime: time.Now().UTC().Truncate(time.Second),
+	}
+	if object.header == nil {
+		object.header = make(http.Header)
+	}
+
+	if f.versioned {
+		object.versionID = fmt.Sprintf("v%04d", f.nextID)
+		f.objects[key] = append(f.objects[key], object)
+	} else {
+		f.objects[key] = []*fakeObject{object}
+	}
+	return object
+}
+
+// latest returns the latest version of a key unless it is missing or a delete marker; callers must hold f.mu
+func (f *fakeS3) latest(key string) *fakeObject {
+	versions := f.objects[key]
+	if len(versions) == 0 || versions[len(versions)-1].deleteMarker {
+		return nil
+	}
+	return versions[len(versions)-1]
+}
+
+// ServeHTTP implements http.Handler
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	bucket, key, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+	if bucket != f.bucket {
+		writeS3Error(w, r, http.StatusNotFound, "NoSuchBucket", bucket)
+		return
+	}
+
+	query := r.URL.Query()
+	if key == "" {
+		f.serveBucket(w, r, query)
+		return
+	}
+
+	if r.Method == http.MethodGet && f.beforeGet != nil {
+		f.beforeGet(key)
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.requests = append(f.requests, r.Method+" "+key)
+
+	switch {
+	case r.Method == http.MethodPost && query.Has("uploads"):
+		f.initiateUpload(w, r, key)
+	case r.Method == http.MethodPut && query.Has("uploadId"):
+		f.uploadPart(w, r, query)
+	case r.Method == http.MethodPost && query.Has("uploadId"):
+		f.completeUpload(w, r, key, query.Get("uploadId"))
+	case r.Method == http.MethodDelete && query.Has("uploadId"):
+		delete(f.uploads, query.Get("uploadId"))
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == http.MethodPut && r.Header.Get("X-Amz-Copy-Source") != "":
+		f.copyObject(w, r, key)
+	case r.Method == http.MethodPut:
+		f.putObject(w, r, key)
+	case r.Method == http.MethodGet || r.Method == http.MethodHead:
+		f.getObject(w, r, key, query.Get("versionId"))
+	case r.Method == http.MethodDelete:
+		f.deleteObject(w, key, query.Get("versionId"))
+	default:
+		writeS3Error(w, r, http.StatusNotImplemented, "NotImplemented", key)
+	}
+}
+
+// serveBucket answers bucket-level requests
+func (f *fakeS3) serveBucket(w http.ResponseWriter, r *http.Request, query url.Values) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	switch {
+	case query.Has("versioning") && r.Method == http.MethodGet:
+		status := ""
+		if f.versioned {
+			status = "Enabled"
+		}
+		writeXML(w, struct {
+			XMLName xml.Name `xml:"VersioningConfiguration"`
+			Status  string   `xml:"Status,omitempty"`
+		}{Status: status})
+	case query.Has("object-lock") && r.Method == http.MethodGet:
+		if f.lockConfig == nil {
+			writeS3Error(w, r, http.StatusNotFound, "ObjectLockConfigurationNotFoundError", "")
+			return
+		}
+		w.Header().Set("Content-Type", "application/xml")
+		w.Write(f.lockConfig)
+	case query.Has("object-lock") && r.Method == http.MethodPut:
+		f.lockConfig, _ = io.ReadAll(r.Body)
+	case query.Has("lifecycle") && r.Method == http.MethodGet:
+		if f.lifecycle == nil {
+			writeS3Error(w, r, http.StatusNotFound, "NoSuchLifecycleConfiguration", "")
+			return
+		}
+		w.Header().Set("Content-Type", "application/xml")
+		w.Write(f.lifecycle)
+	case query.Has("lifecycle") && r.Method == http.MethodPut:
+		f.lifecycle, _ = io.ReadAll(r.Body)
+	case query.Has("lifecycle") && r.Method == http.MethodDelete:
+		f.lifecycle = nil
+		w.WriteHeader(http.StatusNoContent)
+	case query.Has("versions") && r.Method == http.MethodGet:
+		f.listVersions(w, query)
+	case query.Has("delete") && r.Method == http.MethodPost:
+		f.deleteObjects(w, r)
+	case query.Get("list-type") == "2" && r.Method == http.MethodGet:
+		f.listObjects(w, query)
+	default:
+		writeS3Error(w, r, http.StatusNotImplemented, "NotImplemented", "")
+	}
+}
+
+// putObject stores an object, honouring If-Match and If-None-Match
+func (f *fakeS3) putObject(w http.ResponseWriter, r *http.Request, key string) {
+	if !f.checkPreconditions(w, r, key) {
+		return
+	}
+
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeS3Error(w, r, http.StatusBadRequest, "IncompleteBody", key)
+		return
+	}
+
+	object := f.store(key, data, requestHeaders(r), fmt.Sprintf("%x", md5.Sum(data)))
+	writeObjectHeaders(w, object)
+}
+
+// copyObject copies the source version named by X-Amz-Copy-Source
+func (f *fakeS3) copyObject(w http.ResponseWriter, r *http.Request, key string) {
+	source, err := url.PathUnescape(r.Header.Get("X-Amz-Copy-Source"))
+	if err != nil {
+		writeS3Error(w, r, http.StatusBadRequest, "InvalidArgument", key)
+		return
+	}
+	sourcePath, versionID, _ := strings.Cut(strings.TrimPrefix(source, "/"), "?versionId=")
+	_, sourceKey, _ := strings.Cut(sourcePath, "/")
+
+	sourceObject := f.find(sourceKey, versionID)
+	if sourceObject == nil || sourceObject.deleteMarker {
+		writeS3Error(w, r, http.StatusNotFound, "NoSuchKey", sourceKey)
+		return
+	}
+	if match := r.Header.Get("X-Amz-Copy-Source-If-Match"); match != "" && strings.Trim(match, `"`) != sourceObject.etag {
+		writeS3Error(w, r, http.StatusPreconditionFailed, "PreconditionFailed", sourceKey)
+		return
+	}
+
+	header := sourceObject.header.Clone()
+	if r.Header.Get("X-Amz-Metadata-Directive") == "REPLACE" {
+		header = requestHeaders(r)
+	}
+
+	object := f.store(key, slices.Clone(sourceObject.data), header, sourceObject.etag)
+	if object.versionID != "" {
+		w.Header().Set("X-Amz-Version-Id", object.versionID)
+	}
+	writeXML(w, struct {
+		XMLName      xml.Name `xml:"CopyObjectResult"`
+		ETag         string   `xml:"ETag"`
+		LastModified string   `xml:"LastModified"`
+	}{ETag: `"` + object.etag + `"`, LastModified: object.modTime.Format(time.RFC3339)})
+}
+
+// getObject answers GET and HEAD for the latest or a specific version, including byte ranges
+func (f *fakeS3) getObject(w http.ResponseWriter, r *http.Request, key string, versionID string) {
+	object := f.find(key, versionID)
+	if object == nil {
+		writeS3Error(w, r, http.StatusNotFound, "NoSuchKey", key)
+		return
+	}
+	if object.deleteMarker {
+		w.Header().Set("X-Amz-Delete-Marker", "true")
+		w.Header().Set("X-Amz-Version-Id", object.versionID)
+		if versionID == "" {
+			writeS3Error(w, r, http.StatusNotFound, "NoSuchKey", key)
+		} else {
+			writeS3Error(w, r, http.StatusMethodNotAllowed, "MethodNotAllowed", key)
+		}
+		return
+	}
+
+	if keyMD5 := object.header.Get("X-Amz-Server-Side-Encryption-Customer-Key-Md5"); keyMD5 != "" &&
+		r.Header.Get("X-Amz-Server-Side-Encryption-Customer-Key-Md5") != keyMD5 {
+		writeS3Error(w, r, http.StatusBadRequest, "InvalidRequest", key)
+		return
+	}
+	if match := r.Header.Get("If-Match"); match != "" && strings.Trim(match, `"`) != object.etag {
+		writeS3Error(w, r, http.StatusPreconditionFailed, "PreconditionFailed", key)
+		return
+	}
+
+	data := object.data
+	status := http.StatusOK
+	if rangeHeader := r.Header.Get("Range"); rangeHeader != "" {
+		start, end, ok := parseByteRange(rangeHeader, int64(len(data)))
+		if !ok {
+			writeS3Error(w, r, http.StatusRequestedRangeNotSatisfiable, "InvalidRange", key)
+			return
+		}
+		w.Header().Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", start, end, len(data)))
+		data = data[start : end+1]
+		status = http.StatusPartialContent
+	}
+
+	writeObjectHeaders(w, object)
+	w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+	w.WriteHeader(status)
+	if r.Method == http.MethodGet {
+		w.Write(data)
+	}
+}
+
+// deleteObject removes a version, or adds a delete marker when versioning is enabled
+func (f *fakeS3) deleteObject(w http.ResponseWriter, key string, versionID string) {
+	f.remove(key, versionID)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// deleteObjects answers a multi-object delete request
+func (f *fakeS3) deleteObjects(w http.ResponseWriter, r *http.Request) {
+	var request struct {
+		Objects []struct {
+			Key       string `xml:"Key"`
+			VersionID string `xml:"VersionId"`
+		} `xml:"Object"`
+	}
+	if err := xml.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeS3Error(w, r, http.StatusBadRequest, "MalformedXML", "")
+		return
+	}
+
+	type deleted struct {
+		Key       string `xml:"Key"`
+		VersionID string `xml:"VersionId,omitempty"`
+	}
+	result := struct {
+		XMLName xml.Name  `xml:"DeleteResult"`
+		Deleted []deleted `xml:"Deleted"`
+	}{}
+	for _, object := range request.Objects {
+		f.requests = append(f.requests, "DELETE "+object.Key)
+		f.remove(object.Key, object.VersionID)
+		result.Deleted = append(result.Deleted, deleted{Key: object.Key, VersionID: object.VersionID})
+	}
+	writeXML(w, result)
+}
+
+// remove deletes a version, or adds a delete marker when versioning is enabled
+func (f *fakeS3) remove(key string, versionID string) {
+	switch {
+	case versionID != "":
+		f.objects[key] = slices.DeleteFunc(f.objects[key], func(o *fakeObject) bool { return o.versionID == versionID })
+	case f.versioned:
+		marker := f.store(key, nil, nil, "")
+		marker.deleteMarker = true
+	default:
+		delete(f.objects, key)
+	}
+	if len(f.objects[key]) == 0 {
+		delete(f.objects, key)
+	}
+}
+
+// initiateUpload starts a multipart upload
+func (f *fakeS3) initiateUpload(w http.ResponseWriter, r *http.Request, key string) {
+	f.nextID++
+	uploadID := fmt.Sprintf("upload-%d", f.nextID)
+	f.uploads[uploadID] = &fakeUpload{key: key, header: requestHeaders(r), parts: make(map[int][]byte)}
+
+	writeXML(w, struct {
+		XMLName  xml.Name `xml:"InitiateMultipartUploadResult"`
+		Bucket   string   `xml:"Bucket"`
+		Key      string   `xml:"Key"`
+		UploadID string   `xml:"UploadId"`
+	}{Bucket: f.bucket, Key: key, UploadID: uploadID})
+}
+
+// uploadPart stores a part of a multipart upload
+func (f *fakeS3) uploadPart(w http.ResponseWriter, r *http.Request, query url.Values) {
+	upload, ok := f.uploads[query.Get("uploadId")]
+	partNumber, err := strconv.Atoi(query.Get("partNumber"))
+	if !ok || err != nil {
+		writeS3Error(w, r, http.StatusNotFound, "NoSuchUpload", "")
+		return
+	}
+
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeS3Error(w, r, http.StatusBadRequest, "IncompleteBody", upload.key)
+		return
+	}
+	upload.parts[partNumber] = data
+	w.Header().Set("ETag", fmt.Sprintf(`"%x"`, md5.Sum(data)))
+}
+
+// completeUpload concatenates the parts of a multipart upload into an object
+func (f *fakeS3) completeUpload(w http.ResponseWriter, r *http.Request, key string, uploadID string) {
+	upload, ok := f.uploads[uploadID]
+	if !ok {
+		writeS3Error(w, r, http.StatusNotFound, "NoSuchUpload", key)
+		return
+	}
+
+	var complete struct {
+		Parts []struct {
+			PartNumber int `xml:"PartNumber"`
+		} `xml:"Part"`
+	}
+	if err := xml.NewDecoder(r.Body).Decode(&complete); err != nil {
+		writeS3Error(w, r, http.StatusBadRequest, "MalformedXML", key)
+		return
+	}
+
+	var data bytes.Buffer
+	for _, part := range complete.Parts {
+		data.Write(upload.parts[part.PartNumber])
+	}
+	delete(f.uploads, uploadID)
+
+	etag := fmt.Sprintf("%x-%d", md5.Sum(data.Bytes()), len(complete.Parts))
+	object := f.store(key, data.Bytes(), upload.header, etag)
+	if object.versionID != "" {
+		w.Header().Set("X-Amz-Version-Id", object.versionID)
+	}
+	writeXML(w, struct {
+		XMLName xml.Name `xml:"CompleteMultipartUploadResult"`
+		Bucket  string   `xml:"Bucket"`
+		Key     string   `xml:"Key"`
+		ETag    string   `xml:"ETag"`
+	}{Bucket: f.bucket, Key: key, ETag: `"` + etag + `"`})
+}
+
+// listObjects answers ListObjectsV2 with a single page
+func (f *fakeS3) listObjects(w http.ResponseWriter, query url.Values) {
+	type content struct {
+		Key          string `xml:"Key"`
+		LastModified string `xml:"LastModified"`
+		ETag         string `xml:"ETag"`
+		Size         int64  `xml:"Size"`
+		StorageClass string `xml:"StorageClass"`
+	}
+	type commonPrefix struct {
+		Prefix string `xml:"Prefix"`
+	}
+	result := struct {
+		XMLName        xml.Name       `xml:"ListBucketResult"`
+		Name           string         `xml:"Name"`
+		Prefix         string         `xml:"Prefix"`
+		Delimiter      string         `xml:"Delimiter,omitempty"`
+		KeyCount       int            `xml:"KeyCount"`
+		MaxKeys        int            `xml:"MaxKeys"`
+		IsTruncated    bool           `xml:"IsTruncated"`
+		Contents       []content      `xml:"Contents"`
+		CommonPrefixes []commonPrefix `xml:"CommonPrefixes"`
+	}{Name: f.bucket, Prefix: query.Get("prefix"), Delimiter: query.Get("delimiter"), MaxKeys: 1000}
+
+	prefix, delimiter, startAfter := query.Get("prefix"), query.Get("delimiter"), query.Get("start-after")
+	for _, key := range slices.Sorted(maps.Keys(f.objects)) {
+		object := f.latest(key)
+		if object == nil || !strings.HasPrefix(key, prefix) || key <= startAfter {
+			continue
+		}
+
+		rest := strings.TrimPrefix(key, prefix)
+		if i := strings.Index(rest, delimiter); delimiter != "" && i >= 0 {
+			common := prefix + rest[:i+len(delimiter)]
+			if !slices.Contains(result.CommonPrefixes, commonPrefix{common}) {
+				result.CommonPrefixes = append(result.CommonPrefixes, commonPrefix{common})
+			}
+			continue
+		}
+
+		result.Contents = append(result.Contents, content{
+			Key:          key,
+			LastModified: object.modTime.Format(time.RFC3339),
+			ETag:         `"` + object.etag + `"`,
+			Size:         int64(len(object.data)),
+			StorageClass: "STANDARD",
+		})
+	}
+	result.KeyCount = len(result.Contents) + len(result.CommonPrefixes)
+
+	writeXML(w, result)
+}
+
+// listVersions answers ListObjectVersions with a single page, newest version of each key first
+func (f *fakeS3) listVersions(w http.ResponseWriter, query url.Values) {
+	type entry struct {
+		XMLName      xml.Name
+		Key          string `xml:"Key"`
+		VersionID    string `xml:"VersionId"`
+		IsLatest     bool   `xml:"IsLatest"`
+		LastModified string `xml:"LastModified"`
+		ETag         string `xml:"ETag,omitempty"`
+		Size         int64  `xml:"Size"`
+		StorageClass string `xml:"StorageClass,omitempty"`
+	}
+	result := struct {
+		XMLName     xml.Name `xml:"ListVersionsResult"`
+		Name        string   `xml:"Name"`
+		Prefix      string   `xml:"Prefix"`
+		MaxKeys     int      `xml:"MaxKeys"`
+		IsTruncated bool     `xml:"IsTruncated"`
+		Entries     []entry
+	}{Name: f.bucket, Prefix: query.Get("prefix"), MaxKeys: 1000}
+
+	prefix := query.Get("prefix")
+	keys := make([]string, 0, len(f.objects))
+	for key := range f.objects {
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+
+	for _, key := range keys {
+		versions := f.objects[key]
+		for i := len(versions) - 1; i >= 0; i-- {
+			version := versions[i]
+			e := entry{
+				XMLName:      xml.Name{Local: "Version"},
+				Key:          key,
+				VersionID:    version.versionID,
+				IsLatest:     i == len(versions)-1,
+				LastModified: version.modTime.Format(time.RFC3339),
+				ETag:         `"` + version.etag + `"`,
+				Size:         int64(len(version.data)),
+				StorageClass: "STANDARD",
+			}
+			if version.deleteMarker {
+				e.XMLName.Local = "DeleteMarker"
+				e.ETag, e.StorageClass = "", ""
+			}
+			result.Entries = append(result.Entries, e)
+		}
+	}
+
+	writeXML(w, result)
+}
+
+// find returns the latest version of a key, or the given version; callers must hold f.mu
+func (f *fakeS3) find(key string, versionID string) *fakeObject {
+	versions := f.objects[key]
+	if versionID == "" {
+		if len(versions) == 0 {
+			return nil
+		}
+		return versions[len(versions)-1]
+	}
+	for _, version := range versions {
+		if version.versionID == versionID {
+			return version
+		}
+	}
+	return nil
+}
+
+// checkPreconditions applies If-Match and If-None-Match of a write; callers must hold f.mu
+func (f *fakeS3) checkPreconditions(w http.ResponseWriter, r *http.Request, key string) bool {
+	current := f.latest(key)
+	if match := r.Header.Get("If-Match"); match != "" && (current == nil || strings.Trim(match, `"`) != current.etag) {
+		writeS3Error(w, r, http.StatusPreconditionFailed, "PreconditionFailed", key)
+		return false
+	}
+	if r.Header.Get("If-None-Match") == "*" && current != nil {
+		writeS3Error(w, r, http.StatusPreconditionFailed, "PreconditionFailed", key)
+		return false
+	}
+	return true
+}
+
+// requestHeaders returns the request headers that are stored with an object
+func requestHeaders(r *http.Request) http.Header {
+	header := make(http.Header)
+	for name, values := range r.Header {
+		if slices.Contains(storedHeaders, name) || strings.HasPrefix(name, "X-Amz-Meta-") {
+			header[name] = values
+		}
+	}
+	return header
+}
+
+// writeObjectHeaders sets the headers describing a stored object
+func writeObjectHeaders(w http.ResponseWriter, object *fakeObject) {
+	for name, values := range object.header {
+		w.Header()[name] = values
+	}
+	w.Header().Set("ETag", `"`+object.etag+`"`)
+	w.Header().Set("Last-Modified", object.modTime.Format(http.TimeFormat))
+	w.Header().Set("Content-Length", strconv.Itoa(len(object.data)))
+	if object.versionID != "" {
+		w.Header().Set("X-Amz-Version-Id", object.versionID)
+	}
+}
+
+// parseByteRange parses a single "bytes=start-end", "bytes=start-" or "bytes=-suffix" range
+func parseByteRange(header string, size int64) (start int64, end int64, ok bool) {
+	spec, found := strings.CutPrefix(header, "bytes=")
+	if !found {
+		return 0, 0, false
+	}
+	first, last, _ := strings.Cut(spec, "-")
+
+	var err error
+	switch {
+	case first == "":
+		suffix, err := strconv.ParseInt(last, 10, 64)
+		if err != nil || suffix <= 0 {
+			return 0, 0, false
+		}
+		return max(size-suffix, 0), size - 1, size > 0
+	case last == "":
+		end = size - 1
+	default:
+		if end, err = strconv.ParseInt(last, 10, 64); err != nil {
+			return 0, 0, false
+		}
+	}
+	if start, err = strconv.ParseInt(first, 10, 64); err != nil || start >= size || start > end {
+		return 0, 0, false
+	}
+	return start, min(end, size-1), true
+}
+
+// writeXML writes an XML response body
+func writeXML(w http.ResponseWriter, body any) {
+	w.Header().Set("Content-Type", "application/xml")
+	if err := xml.NewEncoder(w).Encode(body); err != nil {
+		panic(err)
+	}
+}
+
+// writeS3Error writes an S3 error response; HEAD responses carry no body
+func writeS3Error(w http.ResponseWriter, r *http.Request, status int, code string, key string) {
+	if r.Method == http.MethodHead {
+		w.WriteHeader(status)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(status)
+	xml.NewEncoder(w).Encode(struct {
+		XMLName   xml.Name `xml:"Error"`
+		Code      string   `xml:"Code"`
+		Message   string   `xml:"Message"`
+		Key       string   `xml:"Key,omitempty"`
+		RequestID string   `xml:"RequestId"`
+	}{Code: code, Message: code, Key: key, RequestID: "fake"})
+}
@@ -0,0 +1,202 @@
+package miniox
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"reflect"
+	"strings"
+
+	"github.com/minio/minio-go/v7"
+)
+
+// SelectCSVOptions configures SelectCSV
+type SelectCSVOptions struct {
+	FileHeaderInfo minio.CSVFileHeaderInfo     // How the first line is treated (default USE, so columns can be referenced by name)
+	FieldDelimiter string                      // Field delimiter of the object (default ",")
+	Comments       string                      // Prefix of comment lines to skip (none when empty)
+	Compression    minio.SelectCompressionType // Compression of the object (default: detected from the key extension or Content-Encoding)
+}
+
+// RowIterator reads the rows returned by SelectCSV
+// Call Next until it returns io.EOF and Close when done, including when stopping early
+type RowIterator struct {
+	ctx     context.Context
+	results *minio.SelectResults
+	reader  *csv.Reader
+}
+
+// Next returns the next row, or io.EOF once all rows were read
+// Errors reported by the server in the event stream and context cancellation are returned as errors
+func (it *RowIterator) Next() ([]string, error) {
+	if err := it.ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	row, err := it.reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, io.EOF
+		}
+		if ctxErr := it.ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
+		return nil, fmt.Errorf("failed to read select results: %w", err)
+	}
+	return row, nil
+}
+
+// Close stops the query and releases the connection
+func (it *RowIterator) Close() error {
+	return it.results.Close()
+}
+
+// SelectCSV runs an S3 Select SQL query against a CSV object and returns an iterator over the result rows
+// Rows are streamed as the server produces them. With the default header handling columns can be referenced by
+// name (e.g. SELECT s.name FROM S3Object s WHERE s.age > '30'); values are returned as strings
+func (c *Client) SelectCSV(ctx context.Context, objectPath string, query string, opts SelectCSVOptions) (*RowIterator, error) {
+	if err := c.validatePath(ctx, objectPath); err != nil {
+		return nil, err
+	}
+
+	compression, err := c.selectCompression(ctx, objectPath, opts.Compression)
+	if err != nil {
+		return nil, err
+	}
+
+	fileHeaderInfo := opts.FileHeaderInfo
+	if fileHeaderInfo == "" {
+		fileHeaderInfo = minio.CSVFileHeaderInfoUse
+	}
+	fieldDelimiter := opts.FieldDelimiter
+	if fieldDelimiter == "" {
+		fieldDelimiter = ","
+	}
+
+	input := &minio.CSVInputOptions{}
+	input.SetFileHeaderInfo(fileHeaderInfo)
+	input.SetFieldDelimiter(fieldDelimiter)
+	if opts.Comments != "" {
+		input.SetComments(opts.Comments)
+	}
+
+	output := &minio.CSVOutputOptions{}
+	output.SetFieldDelimiter(",")
+	output.SetRecordDelimiter("\n")
+	output.SetQuoteFields(minio.CSVQuoteFieldsAsNeeded)
+
+	results, err := c.SelectObjectContent(ctx, objectPath, minio.SelectObjectOptions{
+		ServerSideEncryption: c.readSSE(nil),
+		Expression:           query,
+		ExpressionType:       minio.QueryExpressionTypeSQL,
+		InputSerialization: minio.SelectObjectInputSerialization{
+			CompressionType: compression,
+			CSV:             input,
+		},
+		OutputSerialization: minio.SelectObjectOutputSerialization{CSV: output},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	reader := csv.NewReader(results)
+	// Rows of a projection may have any number of columns
+	reader.FieldsPerRecord = -1
+
+	return &RowIterator{ctx: ctx, results: results, reader: reader}, nil
+}
+
+// SelectJSON runs an S3 Select SQL query against a JSON object and decodes the result records into out
+// out must be a pointer to a slice; each record is decoded into a new element, so a []map[string]any or a slice
+// of structs with json tags both work. Keys ending in .jsonl or .ndjson are read as one document per line, other
+// keys as a single JSON document (use S3Object[*] paths to select array elements). Compression is detected from
+// the key extension or Content-Encoding
+func (c *Client) SelectJSON(ctx context.Context, objectPath string, query string, out any) error {
+	target := reflect.ValueOf(out)
+	if target.Kind() != reflect.Pointer || target.IsNil() || target.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("out must be a non-nil pointer to a slice, got %T", out)
+	}
+
+	if err := c.validatePath(ctx, objectPath); err != nil {
+		return err
+	}
+
+	compression, err := c.selectCompression(ctx, objectPath, "")
+	if err != nil {
+		return err
+	}
+
+	jsonType := minio.JSONDocumentType
+	switch path.Ext(strings.TrimSuffix(strings.TrimSuffix(objectPath, ".gz"), ".bz2")) {
+	case ".jsonl", ".ndjson":
+		jsonType = minio.JSONLinesType
+	}
+
+	input := &minio.JSONInputOptions{}
+	input.SetType(jsonType)
+	output := &minio.JSONOutputOptions{}
+	output.SetRecordDelimiter("\n")
+
+	results, err := c.SelectObjectContent(ctx, objectPath, minio.SelectObjectOptions{
+		ServerSideEncryption: c.readSSE(nil),
+		Expression:           query,
+		ExpressionType:       minio.QueryExpressionTypeSQL,
+		InputSerialization: minio.SelectObjectInputSerialization{
+			CompressionType: compression,
+			JSON:            input,
+		},
+		OutputSerialization: minio.SelectObjectOutputSerialization{JSON: output},
+	})
+	if err != nil {
+		return err
+	}
+	defer results.Close()
+
+	rows := target.Elem()
+	decoder := json.NewDecoder(results)
+	for {
+		row := reflect.New(rows.Type().Elem())
+		if err := decoder.Decode(row.Interface()); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return ctxErr
+			}
+			return fmt.Errorf("failed to decode select results: %w", err)
+		}
+		rows.Set(reflect.Append(rows, row.Elem()))
+	}
+}
+
+// selectCompression returns the compression to declare for a Select query
+// When none is given it is detected from the key extension, then from the Content-Encoding of the object
+func (c *Client) selectCompression(ctx context.Context, objectPath string, compression minio.SelectCompressionType) (minio.SelectCompressionType, error) {
+	if compression != "" {
+		return compression, nil
+	}
+
+	switch strings.ToLower(path.Ext(objectPath)) {
+	case ".gz", ".gzip":
+		return minio.SelectCompressionGZIP, nil
+	case ".bz2":
+		return minio.SelectCompressionBZIP, nil
+	}
+
+	info, err := c.StatObject(ctx, objectPath, minio.StatObjectOptions{})
+	if err != nil {
+		return "", err
+	}
+	switch strings.ToLower(info.Metadata.Get("Content-Encoding")) {
+	case "gzip":
+		return minio.SelectCompressionGZIP, nil
+	case "bzip2":
+		return minio.SelectCompressionBZIP, nil
+	default:
+		return minio.SelectCompressionNONE, nil
+	}
+}
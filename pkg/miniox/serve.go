@@ -0,0 +1,47 @@
+package miniox
+
+import (
+	"log/slog"
+	"net/http"
+	"path"
+
+	"github.com/minio/minio-go/v7"
+)
+
+// ServeObject returns an HTTP handler that serves an object
+// Content-Type, ETag and Last-Modified are set from the object info, and http.ServeContent answers Range,
+// If-Match, If-None-Match, If-Modified-Since and HEAD requests. Missing objects are answered with 404 Not Found,
+// invalid paths with 400 Bad Request and other failures with 502 Bad Gateway. The request context is used for
+// every storage request
+func (c *Client) ServeObject(objectPath string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := c.validatePath(r.Context(), objectPath); err != nil {
+			http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+			return
+		}
+
+		object, info, err := c.GetObjectSeeker(r.Context(), objectPath, minio.GetObjectOptions{})
+		if err != nil {
+			if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+				http.NotFound(w, r)
+				return
+			}
+			c.logInfo("[MinIO] Failed to serve object",
+				slog.String("bucket", c.bucketName),
+				slog.String("object", c.buildPath(r.Context(), objectPath)),
+				slog.String("error", err.Error()))
+			http.Error(w, http.StatusText(http.StatusBadGateway), http.StatusBadGateway)
+			return
+		}
+		defer object.Close()
+
+		if info.ContentType != "" {
+			w.Header().Set("Content-Type", info.ContentType)
+		}
+		if info.ETag != "" {
+			w.Header().Set("ETag", `"`+info.ETag+`"`)
+		}
+
+		http.ServeContent(w, r, path.Base(info.Key), info.LastModified, object)
+	}
+}
@@ -0,0 +1,115 @@
+package miniox
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"github.com/minio/minio-go/v7"
+)
+
+// defaultMaxSortedListObjects bounds the number of objects ListObjectsSorted collects by default
+const defaultMaxSortedListObjects = 100000
+
+// SortField selects the ordering of ListObjectsSorted
+type SortField int
+
+const (
+	SortByKey          SortField = iota // Order by object key
+	SortByLastModified                  // Order by modification time
+	SortBySize                          // Order by size in bytes
+)
+
+// ErrTooManyObjects is returned when a prefix holds more objects than can be collected for sorting
+type ErrTooManyObjects struct {
+	Prefix string // Relative prefix being listed
+	Limit  int    // Configured limit (Config.MaxSortedListObjects)
+}
+
+// Error implements the error interface
+func (e *ErrTooManyObjects) Error() string {
+	return fmt.Sprintf("prefix %q holds more than %d objects, narrow the prefix", e.Prefix, e.Limit)
+}
+
+// ListObjectsSorted recursively lists the objects under a prefix sorted by the given field
+// The whole listing is collected to sort it, so it fails with *ErrTooManyObjects rather than returning a partial
+// result when the prefix holds more than Config.MaxSortedListObjects objects. At most limit objects are returned
+// (all when zero). Ties are broken by key, keys are relative to the base directory prefix and folder markers are skipped
+func (c *Client) ListObjectsSorted(ctx context.Context, prefix string, sortBy SortField, desc bool, limit int) (objects []minio.ObjectInfo, err error) {
+	ctx, span := c.startSpan(ctx, "ListObjectsSorted", slog.String("prefix", prefix))
+	defer func() { span.End(err) }()
+
+	if err := c.validatePath(ctx, prefix); err != nil {
+		return nil, err
+	}
+	if limit < 0 {
+		return nil, fmt.Errorf("limit cannot be negative: %d", limit)
+	}
+
+	fullPrefix := c.buildPrefix(ctx, prefix)
+
+	c.logDebug(ctx, "[MinIO] Listing sorted objects",
+		slog.String("bucket", c.bucketName),
+		slog.String("prefix", fullPrefix),
+		slog.Int("sortBy", int(sortBy)),
+		slog.Bool("desc", desc),
+		slog.Int("limit", limit))
+
+	compare, err := objectComparator(sortBy)
+	if err != nil {
+		return nil, err
+	}
+
+	// Stop the listing as soon as the cap is exceeded
+	listCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	opts := minio.ListObjectsOptions{
+		Prefix:    fullPrefix,
+		Recursive: true,
+	}
+	for objectInfo := range c.minio.ListObjects(listCtx, c.bucketName, opts) {
+		if objectInfo.Err != nil {
+			return nil, objectInfo.Err
+		}
+		if isFolderMarker(objectInfo.Key) {
+			continue
+		}
+		if len(objects) == c.maxSortedListObjects {
+			return nil, &ErrTooManyObjects{Prefix: prefix, Limit: c.maxSortedListObjects}
+		}
+
+		objectInfo.Key = c.stripBasePath(ctx, objectInfo.Key)
+		objects = append(objects, objectInfo)
+	}
+
+	slices.SortFunc(objects, func(a, b minio.ObjectInfo) int {
+		order := cmp.Or(compare(a, b), strings.Compare(a.Key, b.Key))
+		if desc {
+			return -order
+		}
+		return order
+	})
+
+	if limit > 0 && len(objects) > limit {
+		objects = slices.Clip(objects[:limit])
+	}
+	return objects, nil
+}
+
+// objectComparator returns the comparison function for a sort field
+func objectComparator(sortBy SortField) (func(a, b minio.ObjectInfo) int, error) {
+	switch sortBy {
+	case SortByKey:
+		return func(a, b minio.ObjectInfo) int { return strings.Compare(a.Key, b.Key) }, nil
+	case SortByLastModified:
+		return func(a, b minio.ObjectInfo) int { return a.LastModified.Compare(b.LastModified) }, nil
+	case SortBySize:
+		return func(a, b minio.ObjectInfo) int { return cmp.Compare(a.Size, b.Size) }, nil
+	default:
+		return nil, fmt.Errorf("unsupported sort field: %d", sortBy)
+	}
+}
@@ -0,0 +1,80 @@
+package miniox
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"maps"
+
+	"github.com/minio/minio-go/v7"
+)
+
+// Storage classes supported by MinIO
+const (
+	StorageClassStandard          = "STANDARD"
+	StorageClassReducedRedundancy = "REDUCED_REDUNDANCY"
+)
+
+// storageClassHeader is the header carrying the storage class on uploads and copies
+const storageClassHeader = "X-Amz-Storage-Class"
+
+// validateStorageClass checks a storage class against the classes MinIO supports
+func validateStorageClass(storageClass string) error {
+	switch storageClass {
+	case StorageClassStandard, StorageClassReducedRedundancy:
+		return nil
+	default:
+		return fmt.Errorf("unsupported storage class %q: must be %s or %s", storageClass, StorageClassStandard, StorageClassReducedRedundancy)
+	}
+}
+
+// writeStorageClass returns the storage class to apply on uploads, falling back to the configured default
+func (c *Client) writeStorageClass(storageClass string) string {
+	if storageClass != "" {
+		return storageClass
+	}
+	return c.defaultStorageClass
+}
+
+// SetObjectStorageClass moves an existing object to another storage class by copying it onto itself
+// Content headers, user metadata and tags are preserved. The copy is pinned to the current version and fails
+// with *ErrPreconditionFailed if the object changes concurrently
+func (c *Client) SetObjectStorageClass(ctx context.Context, objectPath string, storageClass string) (err error) {
+	ctx, span := c.startOperation(ctx, "SetObjectStorageClass",
+		slog.String("object", objectPath),
+		slog.String("storageClass", storageClass))
+	defer func() { span.End(err) }()
+
+	if err := c.validatePath(ctx, objectPath); err != nil {
+		return err
+	}
+	if err := validateStorageClass(storageClass); err != nil {
+		return err
+	}
+
+	fullPath := c.buildPath(ctx, objectPath)
+
+	// Bypass the stat cache, the copy must preserve the current metadata
+	info, err := withRetry(ctx, c, "SetObjectStorageClass", func() (minio.ObjectInfo, error) {
+		return c.minio.StatObject(ctx, c.bucketName, fullPath, minio.StatObjectOptions{ServerSideEncryption: c.readSSE(nil)})
+	})
+	if err != nil {
+		return err
+	}
+
+	c.logDebug(ctx, "[MinIO] Setting object storage class",
+		slog.String("bucket", c.bucketName),
+		slog.String("object", fullPath),
+		slog.String("from", info.StorageClass),
+		slog.String("to", storageClass))
+
+	// Replacing the metadata is the only way to send a new storage class on a copy
+	userMetadata := maps.Clone(info.UserMetadata)
+	if userMetadata == nil {
+		userMetadata = make(map[string]string, 1)
+	}
+	userMetadata[storageClassHeader] = storageClass
+
+	_, err = c.selfCopy(ctx, objectPath, info, userMetadata, "")
+	return err
+}
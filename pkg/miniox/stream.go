@@ -0,0 +1,60 @@
+package miniox
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/minio/minio-go/v7"
+)
+
+// copyBufferSize is the size of the pooled buffers used to stream objects into writers
+const copyBufferSize = 256 * 1024
+
+// copyBufferPool reuses copy buffers across WriteObjectTo calls
+var copyBufferPool = sync.Pool{
+	New: func() any {
+		buf := make([]byte, copyBufferSize)
+		return &buf
+	},
+}
+
+// WriteObjectTo streams an object into w and returns the number of bytes written
+func (c *Client) WriteObjectTo(ctx context.Context, objectPath string, w io.Writer, opts minio.GetObjectOptions) (int64, error) {
+	written, _, err := c.WriteObjectToWithInfo(ctx, objectPath, w, opts, nil)
+	return written, err
+}
+
+// WriteObjectToWithInfo streams an object into w and returns the number of bytes written and the object info
+// beforeCopy, when not nil, is called with the object info before any byte is written (e.g. to set HTTP
+// headers); returning an error aborts the copy. A failing writer (e.g. a disconnected client) stops the
+// download immediately and its error is returned; errors closing the object never mask a copy error
+func (c *Client) WriteObjectToWithInfo(ctx context.Context, objectPath string, w io.Writer, opts minio.GetObjectOptions, beforeCopy func(minio.ObjectInfo) error) (written int64, info minio.ObjectInfo, err error) {
+	object, info, err := c.OpenObject(ctx, objectPath, opts)
+	if err != nil {
+		return 0, minio.ObjectInfo{}, err
+	}
+	defer func() {
+		if closeErr := object.Close(); closeErr != nil && err == nil {
+			err = fmt.Errorf("failed to close object %s: %w", objectPath, closeErr)
+		}
+	}()
+
+	if beforeCopy != nil {
+		if err := beforeCopy(info); err != nil {
+			return 0, info, err
+		}
+	}
+
+	buf := copyBufferPool.Get().(*[]byte)
+	defer copyBufferPool.Put(buf)
+
+	// Hide any ReaderFrom/WriterTo implementations so the pooled buffer is always used
+	written, err = io.CopyBuffer(struct{ io.Writer }{w}, struct{ io.Reader }{object}, *buf)
+	if err != nil {
+		return written, info, fmt.Errorf("failed to copy object %s: %w", objectPath, err)
+	}
+
+	return written, info, nil
+}
@@ -0,0 +1,108 @@
+package miniox
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/minio/minio-go/v7"
+)
+
+var errDisconnected = errors.New("client disconnected")
+
+// failingWriter accepts limit bytes and then fails, like a response writer whose client went away
+type failingWriter struct {
+	limit   int
+	written int
+}
+
+func (w *failingWriter) Write(p []byte) (int, error) {
+	if w.written+len(p) > w.limit {
+		n := w.limit - w.written
+		w.written = w.limit
+		return n, errDisconnected
+	}
+	w.written += len(p)
+	return len(p), nil
+}
+
+func TestWriteObjectTo(t *testing.T) {
+	ctx := context.Background()
+	c, fake := newFakeS3Client(t, "app-data")
+
+	content := bytes.Repeat([]byte("0123456789"), 20000)
+	fake.put("app-data/export.bin", content, nil)
+
+	var buf bytes.Buffer
+	written, err := c.WriteObjectTo(ctx, "export.bin", &buf, minio.GetObjectOptions{})
+	if err != nil {
+		t.Fatalf("WriteObjectTo: %v", err)
+	}
+	if written != int64(len(content)) || !bytes.Equal(buf.Bytes(), content) {
+		t.Errorf("WriteObjectTo wrote %d bytes, want the %d byte object", written, len(content))
+	}
+
+	if _, err := c.WriteObjectTo(ctx, "missing.bin", &buf, minio.GetObjectOptions{}); minio.ToErrorResponse(err).Code != "NoSuchKey" {
+		t.Errorf("WriteObjectTo of a missing object error = %v, want NoSuchKey", err)
+	}
+}
+
+func TestWriteObjectToWithInfo(t *testing.T) {
+	ctx := context.Background()
+	c, fake := newFakeS3Client(t, "app-data")
+
+	fake.put("app-data/report.csv", []byte("a,b\n1,2\n"), http.Header{"Content-Type": {"text/csv"}})
+
+	var buf bytes.Buffer
+	var seen minio.ObjectInfo
+	written, info, err := c.WriteObjectToWithInfo(ctx, "report.csv", &buf, minio.GetObjectOptions{}, func(info minio.ObjectInfo) error {
+		if buf.Len() != 0 {
+			t.Error("beforeCopy called after bytes were written")
+		}
+		seen = info
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("WriteObjectToWithInfo: %v", err)
+	}
+	if written != 8 || buf.String() != "a,b\n1,2\n" {
+		t.Errorf("WriteObjectToWithInfo wrote %d bytes %q", written, buf.String())
+	}
+	if info.Key != "report.csv" || info.ContentType != "text/csv" || info.Size != 8 {
+		t.Errorf("info = %+v, want report.csv, text/csv, 8 bytes", info)
+	}
+	if seen.ETag != info.ETag {
+		t.Errorf("beforeCopy saw ETag %q, want %q", seen.ETag, info.ETag)
+	}
+
+	// An error from beforeCopy aborts before anything is written
+	errAbort := errors.New("abort")
+	buf.Reset()
+	written, _, err = c.WriteObjectToWithInfo(ctx, "report.csv", &buf, minio.GetObjectOptions{}, func(minio.ObjectInfo) error {
+		return errAbort
+	})
+	if !errors.Is(err, errAbort) || written != 0 || buf.Len() != 0 {
+		t.Errorf("aborted copy = %d bytes, %v, want 0 bytes and the abort error", written, err)
+	}
+}
+
+func TestWriteObjectToFailingWriter(t *testing.T) {
+	ctx := context.Background()
+	c, fake := newFakeS3Client(t, "")
+
+	content := bytes.Repeat([]byte("x"), 256*1024)
+	fake.put("large.bin", content, nil)
+
+	w := &failingWriter{limit: 1000}
+	written, err := c.WriteObjectTo(ctx, "large.bin", w, minio.GetObjectOptions{})
+
+	// The writer error is reported, not masked by closing the half-read object
+	if !errors.Is(err, errDisconnected) {
+		t.Fatalf("WriteObjectTo error = %v, want the writer error", err)
+	}
+	if written != 1000 {
+		t.Errorf("written = %d, want 1000", written)
+	}
+}
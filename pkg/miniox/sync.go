@@ -0,0 +1,554 @@
+package miniox
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+)
+
+// SyncCompare selects how a local file and a remote object are compared during a sync
+type SyncCompare int
+
+const (
+	SyncCompareSizeModTime SyncCompare = iota // Changed when sizes differ or the source is newer than the destination
+	SyncCompareChecksum                       // Changed when sizes differ or the MD5 of the local file differs from the object ETag
+)
+
+// defaultTransferConcurrency is the number of parallel transfers used by syncs and prefix transfers by default
+const defaultTransferConcurrency = 4
+
+// SyncOptions configures SyncLocalToRemote and SyncRemoteToLocal
+type SyncOptions struct {
+	Compare          SyncCompare            // Change detection (default size and modification time)
+	DeleteExtraneous bool                   // Remove destination entries that no longer exist in the source
+	DryRun           bool                   // Only report what would be transferred or deleted
+	Concurrency      int                    // Number of parallel transfers (default 4)
+	Include          []string               // Only sync paths matching one of these globs (see ObjectFilter.Glob); all when empty
+	Exclude          []string               // Skip paths matching one of these globs, even when included
+	PutOptions       minio.PutObjectOptions // Upload options used by SyncLocalToRemote
+}
+
+// validate checks the include and exclude patterns
+func (o SyncOptions) validate() error {
+	for _, pattern := range slices.Concat(o.Include, o.Exclude) {
+		if err := validateGlob(pattern); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// selects reports whether a relative path passes the include and exclude patterns
+// Paths outside the selection are neither transferred nor deleted
+func (o SyncOptions) selects(relativePath string) bool {
+	segments := strings.Split(relativePath, "/")
+	matches := func(pattern string) bool { return matchGlob(strings.Split(pattern, "/"), segments) }
+
+	if len(o.Include) > 0 && !slices.ContainsFunc(o.Include, matches) {
+		return false
+	}
+	return !slices.ContainsFunc(o.Exclude, matches)
+}
+
+// selectSyncPaths drops the local files and remote objects not selected by the options
+func selectSyncPaths(opts SyncOptions, localFiles map[string]localFile, remoteObjects map[string]minio.ObjectInfo) {
+	if len(opts.Include) == 0 && len(opts.Exclude) == 0 {
+		return
+	}
+	maps.DeleteFunc(localFiles, func(relativePath string, _ localFile) bool { return !opts.selects(relativePath) })
+	maps.DeleteFunc(remoteObjects, func(relativePath string, _ minio.ObjectInfo) bool { return !opts.selects(relativePath) })
+}
+
+// SyncReport reports the outcome of a sync
+// Paths are slash-separated, relative to the synced directory or prefix and sorted
+type SyncReport struct {
+	Uploaded   []string         // Files uploaded (or to be uploaded on dry run)
+	Downloaded []string         // Objects downloaded (or to be downloaded on dry run)
+	Deleted    []string         // Destination entries removed (or to be removed on dry run)
+	Skipped    []string         // Unchanged entries
+	Failed     map[string]error // Per-path transfer and removal errors
+
+	BytesTransferred int64 // Total size of transferred files
+}
+
+// fail records a failed path
+func (r *SyncReport) fail(relativePath string, err error) {
+	if r.Failed == nil {
+		r.Failed = make(map[string]error)
+	}
+	r.Failed[relativePath] = err
+}
+
+// err summarizes the failures of a sync, or returns nil when everything succeeded
+func (r *SyncReport) err() error {
+	if len(r.Failed) == 0 {
+		return nil
+	}
+	first := slices.Min(slices.Collect(maps.Keys(r.Failed)))
+	return fmt.Errorf("failed to sync %d paths (e.g. %s): %w", len(r.Failed), first, r.Failed[first])
+}
+
+// localFile describes a regular file found while walking a local directory
+type localFile struct {
+	path    string // Filesystem path
+	size    int64
+	modTime time.Time
+}
+
+// syncPlan is the difference between a source and a destination, as paths relative to the synced root
+type syncPlan struct {
+	transfer   []string // New or changed in the source
+	skipped    []string // Unchanged
+	extraneous []string // Only in the destination
+}
+
+// SyncLocalToRemote uploads new and changed files from a local directory to a prefix
+// Files are compared to the existing objects by size and modification time, or by checksum when
+// opts.Compare is SyncCompareChecksum. With opts.DeleteExtraneous, objects under the prefix without a local
+// file are removed; folder markers are kept. Only regular files are synced, symlinks are not followed.
+// Failed uploads do not stop the sync: they are listed in the report and summarized in the returned error
+func (c *Client) SyncLocalToRemote(ctx context.Context, localDir, remotePrefix string, opts SyncOptions) (report SyncReport, err error) {
+	ctx, span := c.startSpan(ctx, "SyncLocalToRemote",
+		slog.String("localDir", localDir),
+		slog.String("prefix", remotePrefix),
+		slog.Bool("dryRun", opts.DryRun))
+	defer func() { span.End(err) }()
+
+	if err := c.validatePath(ctx, remotePrefix); err != nil {
+		return SyncReport{}, err
+	}
+	if err := opts.validate(); err != nil {
+		return SyncReport{}, err
+	}
+
+	localFiles, err := walkLocalFiles(localDir)
+	if err != nil {
+		return SyncReport{}, err
+	}
+
+	remoteObjects, err := c.listSyncObjects(ctx, remotePrefix)
+	if err != nil {
+		return SyncReport{}, err
+	}
+	selectSyncPaths(opts, localFiles, remoteObjects)
+
+	plan, err := diffSync(opts.Compare, localFiles, remoteObjects, true)
+	if err != nil {
+		return SyncReport{}, err
+	}
+
+	c.logDebug(ctx, "[MinIO] Syncing local directory to prefix",
+		slog.String("bucket", c.bucketName),
+		slog.String("localDir", localDir),
+		slog.String("prefix", remotePrefix),
+		slog.Int("upload", len(plan.transfer)),
+		slog.Int("skip", len(plan.skipped)),
+		slog.Int("extraneous", len(plan.extraneous)),
+		slog.Bool("dryRun", opts.DryRun))
+
+	report.Skipped = plan.skipped
+	if opts.DryRun {
+		report.Uploaded = plan.transfer
+		for _, relativePath := range plan.transfer {
+			report.BytesTransferred += localFiles[relativePath].size
+		}
+		if opts.DeleteExtraneous {
+			report.Deleted = plan.extraneous
+		}
+		return report, nil
+	}
+
+	destPrefix := strings.Trim(toSlash(remotePrefix), "/")
+	failed := runTransfers(ctx, opts.Concurrency, plan.transfer, func(relativePath string) error {
+		file := localFiles[relativePath]
+		return c.uploadLocalFile(ctx, file, joinRelative(destPrefix, relativePath), opts.PutOptions)
+	})
+	for _, relativePath := range plan.transfer {
+		if transferErr, ok := failed[relativePath]; ok {
+			report.fail(relativePath, transferErr)
+			continue
+		}
+		report.Uploaded = append(report.Uploaded, relativePath)
+		report.BytesTransferred += localFiles[relativePath].size
+	}
+
+	if opts.DeleteExtraneous && len(plan.extraneous) > 0 {
+		deleted, failed := c.removeExtraneousObjects(ctx, remotePrefix, plan.extraneous, remoteObjects)
+		report.Deleted = deleted
+		for relativePath, removeErr := range failed {
+			report.fail(relativePath, removeErr)
+		}
+	}
+
+	return report, report.err()
+}
+
+// removeExtraneousObjects removes objects by path relative to the prefix
+// Returns the sorted removed paths and the errors keyed by path
+func (c *Client) removeExtraneousObjects(ctx context.Context, prefix string, relativePaths []string, remoteObjects map[string]minio.ObjectInfo) (deleted []string, failed map[string]error) {
+	objects := make([]minio.ObjectInfo, len(relativePaths))
+	for i, relativePath := range relativePaths {
+		objects[i] = remoteObjects[relativePath]
+	}
+
+	removed, _ := c.removeObjectBatches(ctx, objects, RemoveOptions{ContinueOnError: true}, RemoveResult{})
+
+	// Removal results are relative to the base directory prefix
+	relativeRoot := c.stripBasePath(ctx, c.buildFolderPath(ctx, prefix))
+	for _, key := range removed.Deleted {
+		deleted = append(deleted, strings.TrimPrefix(key, relativeRoot))
+	}
+	slices.Sort(deleted)
+	if len(removed.Errors) > 0 {
+		failed = make(map[string]error, len(removed.Errors))
+		for key, removeErr := range removed.Errors {
+			failed[strings.TrimPrefix(key, relativeRoot)] = removeErr
+		}
+	}
+	return deleted, failed
+}
+
+// SyncRemoteToLocal downloads new and changed objects under a prefix into a local directory
+// Change detection is shared with SyncLocalToRemote, with the object as the source; SyncCompareChecksum compares
+// the object ETag to the MD5 of the local file. Files are written to a temporary file and renamed into place, and
+// get the object's modification time so unchanged objects are skipped on the next run. Keys that cannot be stored
+// below localDir (e.g. containing "..") are never written and are reported as failed. With opts.DeleteExtraneous,
+// local files without a matching object are removed. Folder markers are not downloaded. Failed downloads are
+// listed in the report and summarized in the returned error
+func (c *Client) SyncRemoteToLocal(ctx context.Context, remotePrefix, localDir string, opts SyncOptions) (report SyncReport, err error) {
+	ctx, span := c.startSpan(ctx, "SyncRemoteToLocal",
+		slog.String("prefix", remotePrefix),
+		slog.String("localDir", localDir),
+		slog.Bool("dryRun", opts.DryRun))
+	defer func() { span.End(err) }()
+
+	if err := c.validatePath(ctx, remotePrefix); err != nil {
+		return SyncReport{}, err
+	}
+	if err := opts.validate(); err != nil {
+		return SyncReport{}, err
+	}
+
+	if err := os.MkdirAll(localDir, 0o755); err != nil {
+		return SyncReport{}, err
+	}
+
+	localFiles, err := walkLocalFiles(localDir)
+	if err != nil {
+		return SyncReport{}, err
+	}
+
+	remoteObjects, err := c.listSyncObjects(ctx, remotePrefix)
+	if err != nil {
+		return SyncReport{}, err
+	}
+
+	selectSyncPaths(opts, localFiles, remoteObjects)
+
+	// Keys such as "../x" or "a//b" cannot be stored below the local directory; they are reported as failed
+	localPaths := make(map[string]string, len(remoteObjects))
+	for relativePath, remote := range remoteObjects {
+		localPath, err := filepath.Localize(relativePath)
+		if err != nil {
+			if !isFolderMarker(remote.Key) {
+				report.fail(relativePath, fmt.Errorf("object key %s cannot be stored locally: %w", remote.Key, err))
+			}
+			delete(remoteObjects, relativePath)
+			continue
+		}
+		localPaths[relativePath] = filepath.Join(localDir, localPath)
+	}
+
+	plan, err := diffSync(opts.Compare, localFiles, remoteObjects, false)
+	if err != nil {
+		return SyncReport{}, err
+	}
+
+	c.logDebug(ctx, "[MinIO] Syncing prefix to local directory",
+		slog.String("bucket", c.bucketName),
+		slog.String("prefix", remotePrefix),
+		slog.String("localDir", localDir),
+		slog.Int("download", len(plan.transfer)),
+		slog.Int("skip", len(plan.skipped)),
+		slog.Int("extraneous", len(plan.extraneous)),
+		slog.Bool("dryRun", opts.DryRun))
+
+	report.Skipped = plan.skipped
+	if opts.DryRun {
+		report.Downloaded = plan.transfer
+		for _, relativePath := range plan.transfer {
+			report.BytesTransferred += remoteObjects[relativePath].Size
+		}
+		if opts.DeleteExtraneous {
+			report.Deleted = plan.extraneous
+		}
+		return report, report.err()
+	}
+
+	failed := runTransfers(ctx, opts.Concurrency, plan.transfer, func(relativePath string) error {
+		return c.downloadToLocalFile(ctx, remoteObjects[relativePath], localPaths[relativePath])
+	})
+	for _, relativePath := range plan.transfer {
+		if transferErr, ok := failed[relativePath]; ok {
+			report.fail(relativePath, transferErr)
+			continue
+		}
+		report.Downloaded = append(report.Downloaded, relativePath)
+		report.BytesTransferred += remoteObjects[relativePath].Size
+	}
+
+	if opts.DeleteExtraneous {
+		for _, relativePath := range plan.extraneous {
+			if err := os.Remove(localFiles[relativePath].path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+				report.fail(relativePath, err)
+				continue
+			}
+			report.Deleted = append(report.Deleted, relativePath)
+		}
+	}
+
+	return report, report.err()
+}
+
+// diffSync compares local files and remote objects keyed by relative path
+// upload selects the direction: the local files are the source when true, the objects otherwise. Folder markers
+// are never transferred nor reported as extraneous. All returned paths are sorted
+func diffSync(compare SyncCompare, localFiles map[string]localFile, remoteObjects map[string]minio.ObjectInfo, upload bool) (syncPlan, error) {
+	var plan syncPlan
+
+	for relativePath, remote := range remoteObjects {
+		if isFolderMarker(remote.Key) {
+			continue
+		}
+
+		file, ok := localFiles[relativePath]
+		switch {
+		case !ok && upload:
+			plan.extraneous = append(plan.extraneous, relativePath)
+		case !ok:
+			plan.transfer = append(plan.transfer, relativePath)
+		default:
+			changed, err := syncChanged(compare, file, remote, upload)
+			if err != nil {
+				return syncPlan{}, err
+			}
+			if changed {
+				plan.transfer = append(plan.transfer, relativePath)
+			} else {
+				plan.skipped = append(plan.skipped, relativePath)
+			}
+		}
+	}
+
+	for relativePath := range localFiles {
+		if remote, ok := remoteObjects[relativePath]; ok && !isFolderMarker(remote.Key) {
+			continue
+		}
+		if upload {
+			plan.transfer = append(plan.transfer, relativePath)
+		} else {
+			plan.extraneous = append(plan.extraneous, relativePath)
+		}
+	}
+
+	slices.Sort(plan.transfer)
+	slices.Sort(plan.skipped)
+	slices.Sort(plan.extraneous)
+	return plan, nil
+}
+
+// runTransfers runs transfer for every path with bounded concurrency and returns the errors keyed by path
+// Paths not started because ctx was cancelled are reported with the context error
+func runTransfers(ctx context.Context, concurrency int, relativePaths []string, transfer func(relativePath string) error) map[string]error {
+	if concurrency < 1 {
+		concurrency = defaultTransferConcurrency
+	}
+
+	jobs := make(chan string)
+	go func() {
+		defer close(jobs)
+		for _, relativePath := range relativePaths {
+			jobs <- relativePath
+		}
+	}()
+
+	var (
+		mu     sync.Mutex
+		wg     sync.WaitGroup
+		failed = make(map[string]error)
+	)
+
+	for range concurrency {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for relativePath := range jobs {
+				err := ctx.Err()
+				if err == nil {
+					err = transfer(relativePath)
+				}
+				if err != nil {
+					mu.Lock()
+					failed[relativePath] = err
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	return failed
+}
+
+// downloadToLocalFile downloads an object (full key) to a local path
+// The data is written to a temporary file that replaces the target only once complete
+func (c *Client) downloadToLocalFile(ctx context.Context, remote minio.ObjectInfo, localPath string) (err error) {
+	if err := os.MkdirAll(filepath.Dir(localPath), 0o755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(localPath), "."+filepath.Base(localPath)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tmp.Close()
+			os.Remove(tmp.Name())
+		}
+	}()
+
+	// Pin the download to the listed version so size and modification time stay consistent
+	opts := minio.GetObjectOptions{}
+	if err := opts.SetMatchETag(remote.ETag); err != nil {
+		return err
+	}
+
+	if _, err := c.WriteObjectTo(ctx, c.stripBasePath(ctx, remote.Key), tmp, opts); err != nil {
+		return fmt.Errorf("failed to download %s: %w", remote.Key, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chtimes(tmp.Name(), remote.LastModified, remote.LastModified); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), localPath)
+}
+
+// uploadLocalFile uploads a single local file to a relative object path
+func (c *Client) uploadLocalFile(ctx context.Context, file localFile, objectPath string, opts minio.PutObjectOptions) error {
+	f, err := os.Open(file.path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if _, err := c.PutObject(ctx, objectPath, f, file.size, opts); err != nil {
+		return fmt.Errorf("failed to upload %s: %w", file.path, err)
+	}
+	return nil
+}
+
+// listSyncObjects lists all objects under a prefix keyed by their path relative to the prefix
+// The returned ObjectInfo keys are full keys, as expected by removeObjectBatches
+func (c *Client) listSyncObjects(ctx context.Context, prefix string) (map[string]minio.ObjectInfo, error) {
+	fullPrefix := c.buildFolderPath(ctx, prefix)
+
+	opts := minio.ListObjectsOptions{
+		Prefix:    fullPrefix,
+		Recursive: true,
+	}
+
+	objects := make(map[string]minio.ObjectInfo)
+	for objectInfo := range c.minio.ListObjects(ctx, c.bucketName, opts) {
+		if objectInfo.Err != nil {
+			return nil, objectInfo.Err
+		}
+		objects[strings.TrimPrefix(objectInfo.Key, fullPrefix)] = objectInfo
+	}
+	return objects, nil
+}
+
+// walkLocalFiles returns the regular files below a directory keyed by their slash-separated relative path
+func walkLocalFiles(root string) (map[string]localFile, error) {
+	files := make(map[string]localFile)
+	err := filepath.WalkDir(root, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		relativePath, err := filepath.Rel(root, filePath)
+		if err != nil {
+			return err
+		}
+
+		files[filepath.ToSlash(relativePath)] = localFile{path: filePath, size: info.Size(), modTime: info.ModTime()}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to walk %s: %w", root, err)
+	}
+	return files, nil
+}
+
+// syncChanged reports whether a local file and a remote object differ
+// upload selects which side is the source when comparing modification times
+func syncChanged(compare SyncCompare, file localFile, remote minio.ObjectInfo, upload bool) (bool, error) {
+	if file.size != remote.Size {
+		return true, nil
+	}
+
+	if compare == SyncCompareChecksum {
+		// Multipart and encrypted ETags are not content hashes, so such objects always count as changed
+		if !isPlainMD5ETag(remote.ETag) {
+			return true, nil
+		}
+		sum, err := fileMD5(file.path)
+		if err != nil {
+			return false, err
+		}
+		return !strings.EqualFold(strings.Trim(remote.ETag, `"`), sum), nil
+	}
+
+	if upload {
+		return file.modTime.After(remote.LastModified), nil
+	}
+	return remote.LastModified.After(file.modTime), nil
+}
+
+// fileMD5 returns the hex MD5 of a local file
+func fileMD5(filePath string) (string, error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	hash := md5.New()
+	if _, err := io.Copy(hash, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
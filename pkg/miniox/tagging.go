@@ -0,0 +1,402 @@
+package miniox
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"sync"
+	"sync/atomic"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/tags"
+)
+
+// GetObjectTaggingCount returns the number of tags on an object without fetching the tags
+// The count comes from the object info, so it is served from the stat cache when enabled
+func (c *Client) GetObjectTaggingCount(ctx context.Context, objectPath string) (int, error) {
+	info, err := c.StatObject(ctx, objectPath, minio.StatObjectOptions{})
+	if err != nil {
+		return 0, err
+	}
+	return info.UserTagCount, nil
+}
+
+// GetObjectTag returns the value of a single object tag and whether the tag is set
+func (c *Client) GetObjectTag(ctx context.Context, objectPath string, key string) (string, bool, error) {
+	objectTags, err := c.GetObjectTagging(ctx, objectPath, minio.GetObjectTaggingOptions{})
+	if err != nil {
+		return "", false, err
+	}
+	value, ok := objectTags.ToMap()[key]
+	return value, ok, nil
+}
+
+// SetObjectTag sets a single object tag, keeping the other tags
+// See AddObjectTags for the read-modify-write caveat
+func (c *Client) SetObjectTag(ctx context.Context, objectPath string, key, value string) error {
+	return c.AddObjectTags(ctx, objectPath, map[string]string{key: value})
+}
+
+// AddObjectTags merges tags into the tag set of an object, overwriting the values of existing keys
+// S3 replaces tag sets as a whole and has no conditional tagging request, so the current tags are read, modified
+// and written back: a concurrent tag change made between the read and the write is lost. Nothing is written when
+// the tags are already set
+func (c *Client) AddObjectTags(ctx context.Context, objectPath string, objectTags map[string]string) error {
+	_, err := c.modifyObjectTags(ctx, objectPath, mergeTags(objectTags))
+	return err
+}
+
+// RemoveObjectTag removes a single object tag, keeping the other tags
+// See AddObjectTags for the read-modify-write caveat. Removing a tag that is not set is not an error
+func (c *Client) RemoveObjectTag(ctx context.Context, objectPath string, key string) error {
+	_, err := c.modifyObjectTags(ctx, objectPath, func(current map[string]string) bool {
+		if _, ok := current[key]; !ok {
+			return false
+		}
+		delete(current, key)
+		return true
+	})
+	return err
+}
+
+// mergeTags returns a modifyObjectTags function adding objectTags to the current tags
+func mergeTags(objectTags map[string]string) func(current map[string]string) bool {
+	return func(current map[string]string) bool {
+		changed := false
+		for key, value := range objectTags {
+			if existing, ok := current[key]; !ok || existing != value {
+				current[key] = value
+				changed = true
+			}
+		}
+		return changed
+	}
+}
+
+// modifyObjectTags reads the tags of an object, applies modify and writes them back when modify reports a change
+// An empty result removes the tag set. Returns whether the tags were written
+func (c *Client) modifyObjectTags(ctx context.Context, objectPath string, modify func(current map[string]string) bool) (bool, error) {
+	objectTags, err := c.GetObjectTagging(ctx, objectPath, minio.GetObjectTaggingOptions{})
+	if err != nil {
+		return false, err
+	}
+
+	current := objectTags.ToMap()
+	if !modify(current) {
+		return false, nil
+	}
+	if len(current) == 0 {
+		return true, c.RemoveObjectTagging(ctx, objectPath, minio.RemoveObjectTaggingOptions{})
+	}
+
+	updated, err := tags.MapToObjectTags(current)
+	if err != nil {
+		return false, fmt.Errorf("invalid tags for %s: %w", objectPath, err)
+	}
+	return true, c.PutObjectTagging(ctx, objectPath, updated, minio.PutObjectTaggingOptions{})
+}
+
+// PutObjectTaggingBatch replaces the tags of every object in objectPaths with objectTags
+// Paths are tagged by a pool of concurrency workers (default 4) and failures do not stop the batch; the returned
+// map holds the error of every path that could not be tagged, including invalid paths, and is empty on success.
+// Paths not started because ctx was cancelled are reported with the context error
+func (c *Client) PutObjectTaggingBatch(ctx context.Context, objectPaths []string, objectTags *tags.Tags, concurrency int) map[string]error {
+	ctx, span := c.startSpan(ctx, "PutObjectTaggingBatch", slog.Int("objects", len(objectPaths)))
+
+	c.logDebug(ctx, "[MinIO] Tagging objects",
+		slog.String("bucket", c.bucketName),
+		slog.Int("objects", len(objectPaths)),
+		slog.Int("concurrency", concurrency))
+
+	failed := runTransfers(ctx, concurrency, objectPaths, func(objectPath string) error {
+		return c.PutObjectTagging(ctx, objectPath, objectTags, minio.PutObjectTaggingOptions{})
+	})
+
+	var err error
+	if len(failed) > 0 {
+		first := slices.Min(slices.Collect(maps.Keys(failed)))
+		err = fmt.Errorf("failed to tag %d objects (e.g. %s): %w", len(failed), first, failed[first])
+	}
+	span.End(err)
+
+	return failed
+}
+
+// TagMode selects how TagPrefix applies tags
+type TagMode int
+
+const (
+	TagMerge   TagMode = iota // Add the tags to the existing ones, overwriting the values of existing keys
+	TagReplace                // Replace the whole tag set
+)
+
+// TagPrefixOptions configures TagPrefix
+type TagPrefixOptions struct {
+	Mode         TagMode                     // Merge into or replace the existing tags (default TagMerge)
+	Filter       func(minio.ObjectInfo) bool // Only tag objects for which Filter returns true; receives keys relative to the base directory prefix (nil tags everything)
+	DryRun       bool                        // Only count the objects that would be tagged
+	Concurrency  int                         // Number of objects tagged in parallel (default 4)
+	OpsPerSecond float64                     // Maximum tagging requests per second for this call (unlimited when zero)
+}
+
+// TagReport reports the outcome of TagPrefix
+type TagReport struct {
+	Tagged  int              // Objects tagged (or to be tagged on dry run)
+	Skipped int              // Objects left out by the filter, or already carrying the tags in merge mode
+	Failed  map[string]error // Per-object errors keyed by path relative to the base directory
+}
+
+// TagPrefix applies tags to every object under a prefix
+// Objects are tagged with bounded concurrency and failures do not stop the run: they are listed in the report and
+// summarized in the returned error. Merge mode reads each object's tags first and skips objects that already carry
+// them (see AddObjectTags for the read-modify-write caveat); a dry run makes no tagging requests, so in merge mode it
+// counts such objects as tagged. opts.OpsPerSecond throttles the tagging requests of this call on top of the
+// client-wide rate limits
+func (c *Client) TagPrefix(ctx context.Context, prefix string, tagsToApply map[string]string, opts TagPrefixOptions) (report TagReport, err error) {
+	ctx, span := c.startSpan(ctx, "TagPrefix",
+		slog.String("prefix", prefix),
+		slog.Bool("dryRun", opts.DryRun))
+	defer func() { span.End(err) }()
+
+	if err := c.validatePath(ctx, prefix); err != nil {
+		return TagReport{}, err
+	}
+	objectTags, err := tags.MapToObjectTags(tagsToApply)
+	if err != nil {
+		return TagReport{}, fmt.Errorf("invalid tags: %w", err)
+	}
+
+	listCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var objectPaths []string
+	for objectInfo := range c.ListObjectsWithOpts(listCtx, prefix, ListObjectsOpts{Recursive: true, HideFolderMarkers: true}) {
+		if objectInfo.Err != nil {
+			return TagReport{}, objectInfo.Err
+		}
+		if opts.Filter != nil && !opts.Filter(objectInfo) {
+			report.Skipped++
+			continue
+		}
+		objectPaths = append(objectPaths, objectInfo.Key)
+	}
+
+	c.logDebug(ctx, "[MinIO] Tagging prefix",
+		slog.String("bucket", c.bucketName),
+		slog.String("prefix", c.buildPrefix(ctx, prefix)),
+		slog.Int("objects", len(objectPaths)),
+		slog.Int("filtered", report.Skipped),
+		slog.Bool("replace", opts.Mode == TagReplace),
+		slog.Bool("dryRun", opts.DryRun))
+
+	if opts.DryRun {
+		report.Tagged = len(objectPaths)
+		return report, nil
+	}
+
+	// Merge mode makes two requests per object
+	requests := 1.0
+	if opts.Mode != TagReplace {
+		requests = 2
+	}
+	limiter := newTokenBucket(opts.OpsPerSecond, max(opts.OpsPerSecond, requests))
+
+	var tagged, unchanged atomic.Int64
+	failed := runTransfers(ctx, opts.Concurrency, objectPaths, func(objectPath string) error {
+		if err := limiter.wait(ctx, requests); err != nil {
+			return err
+		}
+
+		if opts.Mode == TagReplace {
+			if err := c.PutObjectTagging(ctx, objectPath, objectTags, minio.PutObjectTaggingOptions{}); err != nil {
+				return err
+			}
+			tagged.Add(1)
+			return nil
+		}
+
+		changed, err := c.modifyObjectTags(ctx, objectPath, mergeTags(tagsToApply))
+		if err != nil {
+			return err
+		}
+		if changed {
+			tagged.Add(1)
+		} else {
+			unchanged.Add(1)
+		}
+		return nil
+	})
+
+	report.Tagged = int(tagged.Load())
+	report.Skipped += int(unchanged.Load())
+	if len(failed) > 0 {
+		report.Failed = failed
+		first := slices.Min(slices.Collect(maps.Keys(failed)))
+		return report, fmt.Errorf("failed to tag %d objects (e.g. %s): %w", len(failed), first, failed[first])
+	}
+	return report, nil
+}
+
+// FindObjectsByTag returns the paths of the objects under a prefix whose tag tagKey has the value tagValue
+// An empty tagValue matches any object on which tagKey is set. It collects the results of ListObjectsByTag, so the
+// same search rules apply. Paths are relative to the base directory and sorted
+func (c *Client) FindObjectsByTag(ctx context.Context, prefix string, tagKey, tagValue string) ([]string, error) {
+	var paths []string
+	for object := range c.ListObjectsByTag(ctx, prefix, tagKey, tagValue) {
+		if object.Info.Err != nil {
+			return nil, object.Info.Err
+		}
+		paths = append(paths, object.Info.Key)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	slices.Sort(paths)
+	return paths, nil
+}
+
+// defaultTagListConcurrency is the default number of parallel tag requests of ListObjectsByTag
+const defaultTagListConcurrency = 16
+
+// TaggedObject is an object found by ListObjectsByTag together with its tags
+// Info.Err is set when the search failed; it is the last value sent on the channel
+type TaggedObject struct {
+	Info minio.ObjectInfo  // Object info with the key relative to the base directory prefix
+	Tags map[string]string // Complete tag set of the object
+}
+
+// ListByTagOptions configures ListObjectsByTagWithOpts
+type ListByTagOptions struct {
+	Concurrency int // Number of parallel tag requests (default 16)
+}
+
+// ListObjectsByTag streams the objects under a prefix whose tag tagKey has the value tagValue
+// See ListObjectsByTagWithOpts
+func (c *Client) ListObjectsByTag(ctx context.Context, prefix string, tagKey, tagValue string) <-chan TaggedObject {
+	return c.ListObjectsByTagWithOpts(ctx, prefix, tagKey, tagValue, ListByTagOptions{})
+}
+
+// ListObjectsByTagWithOpts streams the objects under a prefix carrying a tag with the given options
+// An empty tagValue matches any object on which tagKey is set. S3 has no server-side tag filtering, so the prefix
+// is listed and the tags of every object are fetched with bounded concurrency, except for tags MinIO reports in
+// the listing itself. Matches are sent as they are found, in no particular order; objects removed during the
+// search are ignored. The channel is closed once the search completes, fails or ctx is cancelled
+func (c *Client) ListObjectsByTagWithOpts(ctx context.Context, prefix string, tagKey, tagValue string, opts ListByTagOptions) <-chan TaggedObject {
+	ctx, span := c.startSpan(ctx, "ListObjectsByTag",
+		slog.String("prefix", prefix),
+		slog.String("tagKey", tagKey))
+
+	if tagKey == "" {
+		err := fmt.Errorf("tag key cannot be empty")
+		span.End(err)
+		errorCh := make(chan TaggedObject, 1)
+		errorCh <- TaggedObject{Info: minio.ObjectInfo{Err: err}}
+		close(errorCh)
+		return errorCh
+	}
+
+	concurrency := opts.Concurrency
+	if concurrency < 1 {
+		concurrency = defaultTagListConcurrency
+	}
+
+	c.logDebug(ctx, "[MinIO] Listing objects by tag",
+		slog.String("bucket", c.bucketName),
+		slog.String("prefix", c.buildPrefix(ctx, prefix)),
+		slog.String("tagKey", tagKey),
+		slog.Int("concurrency", concurrency))
+
+	results := make(chan TaggedObject)
+	go func() {
+		searchCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		var (
+			wg      sync.WaitGroup
+			errOnce sync.Once
+			err     error
+		)
+		fail := func(failErr error) {
+			errOnce.Do(func() {
+				err = failErr
+				cancel()
+			})
+		}
+		send := func(object TaggedObject) {
+			select {
+			case results <- object:
+			case <-searchCtx.Done():
+			}
+		}
+		matches := func(objectTags map[string]string) bool {
+			value, ok := objectTags[tagKey]
+			return ok && (tagValue == "" || value == tagValue)
+		}
+
+		jobs := make(chan minio.ObjectInfo)
+		for range concurrency {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for objectInfo := range jobs {
+					objectTags, tagErr := c.GetObjectTagging(searchCtx, objectInfo.Key, minio.GetObjectTaggingOptions{})
+					switch {
+					case tagErr != nil && minio.ToErrorResponse(tagErr).Code == "NoSuchKey":
+					case tagErr != nil:
+						fail(fmt.Errorf("failed to get tags of %s: %w", objectInfo.Key, tagErr))
+					case matches(objectTags.ToMap()):
+						send(TaggedObject{Info: objectInfo, Tags: objectTags.ToMap()})
+					}
+				}
+			}()
+		}
+
+		listing := c.ListObjectsWithOpts(searchCtx, prefix, ListObjectsOpts{Recursive: true, WithMetadata: true, HideFolderMarkers: true})
+		for objectInfo := range listing {
+			switch {
+			case searchCtx.Err() != nil:
+				// Drain the listing after a failure or cancellation
+			case objectInfo.Err != nil:
+				fail(objectInfo.Err)
+			case objectInfo.UserTags != nil:
+				if matches(objectInfo.UserTags) {
+					send(TaggedObject{Info: objectInfo, Tags: objectInfo.UserTags})
+				}
+			default:
+				select {
+				case jobs <- objectInfo:
+				case <-searchCtx.Done():
+				}
+			}
+		}
+		close(jobs)
+		wg.Wait()
+
+		if err == nil {
+			err = ctx.Err()
+		}
+		span.End(err)
+		if err != nil && ctx.Err() == nil {
+			results <- TaggedObject{Info: minio.ObjectInfo{Err: err}}
+		}
+		close(results)
+	}()
+
+	return results
+}
+
+// CountObjectsByTag counts the objects under a prefix whose tag tagKey has the value tagValue
+// An empty tagValue counts every object on which tagKey is set. See ListObjectsByTagWithOpts
+func (c *Client) CountObjectsByTag(ctx context.Context, prefix string, tagKey, tagValue string) (int, error) {
+	count := 0
+	for object := range c.ListObjectsByTag(ctx, prefix, tagKey, tagValue) {
+		if object.Info.Err != nil {
+			return count, object.Info.Err
+		}
+		count++
+	}
+	return count, ctx.Err()
+}
@@ -0,0 +1,148 @@
+package miniox
+
+import (
+	"context"
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// RateLimits configures client-side throttling; zero values disable the corresponding limit
+type RateLimits struct {
+	OpsPerSecond           float64 // Maximum HTTP requests per second across all operations
+	MaxUploadBytesPerSec   int64   // Maximum request body throughput in bytes per second
+	MaxDownloadBytesPerSec int64   // Maximum response body throughput in bytes per second
+	MaxBytesPerSecond      int64   // Maximum combined upload and download throughput in bytes per second
+}
+
+// rateLimiter holds the token buckets shared by a client and all clients derived from it
+// Each bucket is nil while its limit is unset, so unthrottled requests only pay for an atomic load
+type rateLimiter struct {
+	ops      atomic.Pointer[tokenBucket]
+	upload   atomic.Pointer[tokenBucket]
+	download atomic.Pointer[tokenBucket]
+	transfer atomic.Pointer[tokenBucket] // Shared by uploads and downloads
+}
+
+// set replaces all limits; buckets are recreated so a new limit takes effect immediately
+func (rl *rateLimiter) set(limits RateLimits) {
+	rl.ops.Store(newTokenBucket(limits.OpsPerSecond, max(limits.OpsPerSecond, 1)))
+	rl.upload.Store(newTokenBucket(float64(limits.MaxUploadBytesPerSec), float64(limits.MaxUploadBytesPerSec)))
+	rl.download.Store(newTokenBucket(float64(limits.MaxDownloadBytesPerSec), float64(limits.MaxDownloadBytesPerSec)))
+	rl.transfer.Store(newTokenBucket(float64(limits.MaxBytesPerSecond), float64(limits.MaxBytesPerSecond)))
+}
+
+// get returns the current limits
+func (rl *rateLimiter) get() RateLimits {
+	return RateLimits{
+		OpsPerSecond:           rl.ops.Load().limit(),
+		MaxUploadBytesPerSec:   int64(rl.upload.Load().limit()),
+		MaxDownloadBytesPerSec: int64(rl.download.Load().limit()),
+		MaxBytesPerSecond:      int64(rl.transfer.Load().limit()),
+	}
+}
+
+// SetRateLimits changes the client throttles at runtime
+// Limits are shared with every client derived via WithPrefix and apply to all goroutines using them
+func (c *Client) SetRateLimits(limits RateLimits) {
+	c.rateLimiter.set(limits)
+}
+
+// GetRateLimits returns the current client throttles
+func (c *Client) GetRateLimits() RateLimits {
+	return c.rateLimiter.get()
+}
+
+// tokenBucket is a concurrency-safe token bucket; callers reserve tokens and sleep off any deficit
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64 // Tokens added per second
+	burst  float64 // Maximum number of stored tokens
+	tokens float64
+	last   time.Time
+}
+
+// newTokenBucket creates a full token bucket, or returns nil when rate <= 0
+func newTokenBucket(rate, burst float64) *tokenBucket {
+	if rate <= 0 {
+		return nil
+	}
+	return &tokenBucket{rate: rate, burst: burst, tokens: burst, last: time.Now()}
+}
+
+// limit returns the bucket rate; a nil bucket is unlimited
+func (b *tokenBucket) limit() float64 {
+	if b == nil {
+		return 0
+	}
+	return b.rate
+}
+
+// wait takes n tokens, blocking until they are available or ctx is done
+// Requests larger than the burst are allowed and simply wait for the deficit to refill
+func (b *tokenBucket) wait(ctx context.Context, n float64) error {
+	if b == nil || n <= 0 {
+		return nil
+	}
+
+	b.mu.Lock()
+	now := time.Now()
+	b.tokens = min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+	b.tokens -= n
+	delay := time.Duration(-b.tokens / b.rate * float64(time.Second))
+	b.mu.Unlock()
+
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		// Give back the reservation so other callers are not delayed by a cancelled request
+		b.mu.Lock()
+		b.tokens += n
+		b.mu.Unlock()
+		return ctx.Err()
+	}
+}
+
+// throttledReader limits the throughput of a request or response body
+// Buckets are loaded on every read so runtime limit changes apply to transfers already in progress
+type throttledReader struct {
+	ctx       context.Context
+	reader    io.ReadCloser
+	direction *atomic.Pointer[tokenBucket] // Upload or download limit
+	transfer  *atomic.Pointer[tokenBucket] // Combined limit
+}
+
+// Read implements io.Reader
+func (r *throttledReader) Read(p []byte) (int, error) {
+	direction, transfer := r.direction.Load(), r.transfer.Load()
+	for _, bucket := range []*tokenBucket{direction, transfer} {
+		if bucket != nil && len(p) > int(bucket.burst) {
+			// Keep reads within one second of throughput so the rate stays smooth
+			p = p[:max(int(bucket.burst), 1)]
+		}
+	}
+
+	n, err := r.reader.Read(p)
+	if waitErr := direction.wait(r.ctx, float64(n)); waitErr != nil && err == nil {
+		err = waitErr
+	}
+	if waitErr := transfer.wait(r.ctx, float64(n)); waitErr != nil && err == nil {
+		err = waitErr
+	}
+	return n, err
+}
+
+// Close implements io.Closer
+func (r *throttledReader) Close() error {
+	return r.reader.Close()
+}
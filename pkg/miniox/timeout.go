@@ -0,0 +1,35 @@
+package miniox
+
+import (
+	"context"
+	"log/slog"
+)
+
+// timeoutSpan cancels the operation timeout context when the span ends
+type timeoutSpan struct {
+	Span
+	cancel context.CancelFunc
+}
+
+// End finishes the span and releases the timeout context
+func (s timeoutSpan) End(err error) {
+	s.Span.End(err)
+	s.cancel()
+}
+
+// startOperation starts a span for a request/response operation and applies the configured operation timeout
+// The timeout is only applied when ctx has no deadline of its own, and is released when the span ends.
+// Streaming operations whose results outlive the call (GetObject, ListObjects, ...) must use startSpan instead
+func (c *Client) startOperation(ctx context.Context, operation string, attrs ...slog.Attr) (context.Context, Span) {
+	ctx, span := c.startSpan(ctx, operation, attrs...)
+
+	if c.operationTimeout <= 0 {
+		return ctx, span
+	}
+	if _, hasDeadline := ctx.Deadline(); hasDeadline {
+		return ctx, span
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.operationTimeout)
+	return ctx, timeoutSpan{Span: span, cancel: cancel}
+}
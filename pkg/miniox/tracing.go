@@ -0,0 +1,48 @@
+package miniox
+
+import (
+	"context"
+	"log/slog"
+)
+
+// Tracer starts spans around client operations
+// Implementations adapt a concrete tracing backend (see the otelx subpackage for OpenTelemetry)
+// so the core package does not depend on any tracing library.
+type Tracer interface {
+	// Start begins a span as a child of any span carried by ctx and returns the derived context
+	Start(ctx context.Context, spanName string, attrs ...slog.Attr) (context.Context, Span)
+}
+
+// Span represents a single traced operation started by a Tracer
+type Span interface {
+	// SetAttributes adds attributes to the span
+	SetAttributes(attrs ...slog.Attr)
+	// End finishes the span, marking it as failed when err is not nil
+	End(err error)
+}
+
+// noopSpan is used when no tracer is configured
+type noopSpan struct{}
+
+func (noopSpan) SetAttributes(...slog.Attr) {}
+func (noopSpan) End(error)                  {}
+
+// startSpan starts a span named "miniox.<operation>" when a tracer is configured
+// The returned context must be passed to the underlying MinIO call so HTTP-level instrumentation can attach.
+// When a metrics observer is configured, the operation is also timed and reported when the span ends
+func (c *Client) startSpan(ctx context.Context, operation string, attrs ...slog.Attr) (context.Context, Span) {
+	var span Span = noopSpan{}
+	if c.tracer != nil {
+		spanAttrs := make([]slog.Attr, 0, len(attrs)+1)
+		spanAttrs = append(spanAttrs, slog.String("bucket", c.bucketName))
+		spanAttrs = append(spanAttrs, attrs...)
+
+		ctx, span = c.tracer.Start(ctx, "miniox."+operation, spanAttrs...)
+	}
+
+	if c.metrics != nil {
+		ctx, span = c.observeOperation(ctx, operation, span)
+	}
+
+	return ctx, span
+}
@@ -0,0 +1,123 @@
+package miniox
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/minio/minio-go/v7"
+)
+
+// TransferResult reports the outcome of TransferPrefix
+// Paths are relative to the source and destination prefixes
+type TransferResult struct {
+	Transferred []string         // Objects copied, sorted
+	Errors      map[string]error // Per-object errors
+
+	BytesTransferred int64 // Total size of copied objects
+}
+
+// Transfer streams an object from one client to another, e.g. to migrate data between clusters
+// Both clients apply their own bucket, base directory prefix and encryption settings. Content headers and user
+// metadata are carried over; tags, retention and storage class are not
+func Transfer(ctx context.Context, src *Client, srcPath string, dst *Client, dstPath string) (minio.UploadInfo, error) {
+	return transferObject(ctx, src, srcPath, dst, dstPath, nil)
+}
+
+// transferObject streams an object from one client to another, applying userTags to the destination object
+func transferObject(ctx context.Context, src *Client, srcPath string, dst *Client, dstPath string, userTags map[string]string) (uploadInfo minio.UploadInfo, err error) {
+	ctx, span := dst.startSpan(ctx, "Transfer",
+		slog.String("src", srcPath),
+		slog.String("srcBucket", src.bucketName),
+		slog.String("dest", dstPath))
+	defer func() { span.End(err) }()
+
+	if err := dst.validatePath(ctx, dstPath); err != nil {
+		return minio.UploadInfo{}, err
+	}
+
+	object, info, err := src.OpenObject(ctx, srcPath, minio.GetObjectOptions{})
+	if err != nil {
+		return minio.UploadInfo{}, err
+	}
+	defer object.Close()
+
+	dst.logDebug(ctx, "[MinIO] Transferring object between clients",
+		slog.String("srcBucket", src.bucketName),
+		slog.String("src", srcPath),
+		slog.String("bucket", dst.bucketName),
+		slog.String("dest", dstPath),
+		slog.Int64("size", info.Size))
+
+	opts := minio.PutObjectOptions{
+		UserMetadata:       info.UserMetadata,
+		ContentType:        info.ContentType,
+		ContentEncoding:    info.Metadata.Get("Content-Encoding"),
+		ContentDisposition: info.Metadata.Get("Content-Disposition"),
+		ContentLanguage:    info.Metadata.Get("Content-Language"),
+		CacheControl:       info.Metadata.Get("Cache-Control"),
+		Expires:            info.Expires,
+		UserTags:           userTags,
+	}
+
+	return dst.PutObject(ctx, dstPath, object, info.Size, opts)
+}
+
+// TransferPrefix transfers every object under a prefix from one client to another with bounded concurrency
+// Objects keep their path relative to the prefix. Concurrency defaults to 4. A failed object does not stop the
+// transfer: it is recorded in the result and summarized in the returned error
+func TransferPrefix(ctx context.Context, src *Client, srcPrefix string, dst *Client, dstPrefix string, concurrency int) (result TransferResult, err error) {
+	ctx, span := dst.startSpan(ctx, "TransferPrefix",
+		slog.String("srcPrefix", srcPrefix),
+		slog.String("srcBucket", src.bucketName),
+		slog.String("destPrefix", dstPrefix))
+	defer func() { span.End(err) }()
+
+	if err := src.validatePath(ctx, srcPrefix); err != nil {
+		return TransferResult{}, err
+	}
+	if err := dst.validatePath(ctx, dstPrefix); err != nil {
+		return TransferResult{}, err
+	}
+
+	objects, err := src.listSyncObjects(ctx, srcPrefix)
+	if err != nil {
+		return TransferResult{}, err
+	}
+	relativePaths := slices.Sorted(maps.Keys(objects))
+
+	dst.logDebug(ctx, "[MinIO] Transferring prefix between clients",
+		slog.String("srcBucket", src.bucketName),
+		slog.String("srcPrefix", srcPrefix),
+		slog.String("bucket", dst.bucketName),
+		slog.String("destPrefix", dstPrefix),
+		slog.Int("objects", len(relativePaths)))
+
+	cleanSrcPrefix := strings.Trim(srcPrefix, "/")
+	cleanDstPrefix := strings.Trim(dstPrefix, "/")
+	failed := runTransfers(ctx, concurrency, relativePaths, func(relativePath string) error {
+		_, err := Transfer(ctx, src, joinRelative(cleanSrcPrefix, relativePath), dst, joinRelative(cleanDstPrefix, relativePath))
+		return err
+	})
+
+	for _, relativePath := range relativePaths {
+		if transferErr, ok := failed[relativePath]; ok {
+			if result.Errors == nil {
+				result.Errors = make(map[string]error)
+			}
+			result.Errors[relativePath] = transferErr
+			continue
+		}
+		result.Transferred = append(result.Transferred, relativePath)
+		result.BytesTransferred += objects[relativePath].Size
+	}
+
+	if len(result.Errors) > 0 {
+		first := slices.Min(slices.Collect(maps.Keys(result.Errors)))
+		return result, fmt.Errorf("failed to transfer %d objects (e.g. %s): %w", len(result.Errors), first, result.Errors[first])
+	}
+	return result, nil
+}
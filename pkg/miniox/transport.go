@@ -0,0 +1,57 @@
+package miniox
+
+import (
+	"net/http"
+)
+
+// clientTransport applies the client rate limits and byte metrics to every HTTP request made by the MinIO client
+type clientTransport struct {
+	base    http.RoundTripper
+	limiter *rateLimiter
+	metrics MetricsObserver
+}
+
+// CloseIdleConnections closes the idle connections of the base transport when it pools connections
+func (t *clientTransport) CloseIdleConnections() {
+	if closer, ok := t.base.(interface{ CloseIdleConnections() }); ok {
+		closer.CloseIdleConnections()
+	}
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *clientTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	if err := t.limiter.ops.Load().wait(ctx, 1); err != nil {
+		if req.Body != nil {
+			req.Body.Close()
+		}
+		return nil, err
+	}
+
+	hasBody := req.Body != nil && req.Body != http.NoBody
+	throttleUpload := hasBody && (t.limiter.upload.Load() != nil || t.limiter.transfer.Load() != nil)
+	if throttleUpload || (hasBody && t.metrics != nil) {
+		req = req.Clone(ctx)
+		if throttleUpload {
+			req.Body = &throttledReader{ctx: ctx, reader: req.Body, direction: &t.limiter.upload, transfer: &t.limiter.transfer}
+		}
+		if t.metrics != nil {
+			req.Body = &countingReader{reader: req.Body, observe: t.byteObserver(ctx, BytesUploaded)}
+		}
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Body != nil && (t.limiter.download.Load() != nil || t.limiter.transfer.Load() != nil) {
+		resp.Body = &throttledReader{ctx: ctx, reader: resp.Body, direction: &t.limiter.download, transfer: &t.limiter.transfer}
+	}
+	if resp.Body != nil && t.metrics != nil {
+		resp.Body = &countingReader{reader: resp.Body, observe: t.byteObserver(ctx, BytesDownloaded)}
+	}
+
+	return resp, nil
+}
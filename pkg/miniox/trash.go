@@ -0,0 +1,182 @@
+package miniox
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+)
+
+const (
+	// defaultTrashPrefix is the folder used for soft-deleted objects when none is configured
+	defaultTrashPrefix = ".trash"
+
+	// trashTimeLayout formats the deletion time segment of a trash key; it sorts lexically by time
+	trashTimeLayout = "20060102T150405.000000000Z"
+)
+
+// TrashItem describes a soft-deleted object
+type TrashItem struct {
+	TrashKey     string    // Relative key of the object inside the trash
+	OriginalPath string    // Relative path the object was trashed from
+	DeletedAt    time.Time // Time the object was moved to the trash
+	Size         int64     // Object size in bytes
+}
+
+// RestoreOptions configures RestoreFromTrash
+type RestoreOptions struct {
+	Overwrite bool // Replace an object that already exists at the original path
+}
+
+// GetTrashPrefix returns the configured trash prefix (relative to the base directory prefix)
+func (c *Client) GetTrashPrefix() string {
+	return c.trashPrefix
+}
+
+// TrashObject soft-deletes an object by moving it to <trash prefix>/<timestamp>/<object path>
+// The trash lives under the base directory prefix, so tenants stay isolated. Returns the relative trash key
+func (c *Client) TrashObject(ctx context.Context, objectPath string) (trashKey string, err error) {
+	ctx, span := c.startOperation(ctx, "TrashObject", slog.String("object", objectPath))
+	defer func() { span.End(err) }()
+
+	if err := c.validatePath(ctx, objectPath); err != nil {
+		return "", err
+	}
+
+	relativePath := c.stripBasePath(ctx, c.buildPath(ctx, objectPath))
+	if relativePath == "" {
+		return "", fmt.Errorf("object path is required")
+	}
+	if c.isTrashKey(relativePath) {
+		return "", fmt.Errorf("object is already in trash: %s", objectPath)
+	}
+
+	trashKey = c.trashPrefix + "/" + time.Now().UTC().Format(trashTimeLayout) + "/" + relativePath
+
+	c.logDebug(ctx, "[MinIO] Moving object to trash",
+		slog.String("bucket", c.bucketName),
+		slog.String("object", relativePath),
+		slog.String("trashKey", trashKey))
+
+	if _, err := c.CopyObject(ctx, trashKey, relativePath, minio.CopyDestOptions{}); err != nil {
+		return "", fmt.Errorf("failed to copy object to trash: %w", err)
+	}
+
+	if err := c.RemoveObject(ctx, relativePath, minio.RemoveObjectOptions{}); err != nil {
+		return trashKey, fmt.Errorf("object copied to trash but original could not be removed: %w", err)
+	}
+
+	return trashKey, nil
+}
+
+// RestoreFromTrash moves a trashed object back to its original path
+// Restoring over an existing object fails unless opts.Overwrite is set
+func (c *Client) RestoreFromTrash(ctx context.Context, trashKey string, opts RestoreOptions) (err error) {
+	ctx, span := c.startOperation(ctx, "RestoreFromTrash", slog.String("object", trashKey))
+	defer func() { span.End(err) }()
+
+	if err := c.validatePath(ctx, trashKey); err != nil {
+		return err
+	}
+
+	originalPath, _, err := c.parseTrashKey(trashKey)
+	if err != nil {
+		return err
+	}
+
+	c.logDebug(ctx, "[MinIO] Restoring object from trash",
+		slog.String("bucket", c.bucketName),
+		slog.String("trashKey", trashKey),
+		slog.String("object", originalPath))
+
+	if !opts.Overwrite {
+		_, err := c.StatObject(ctx, originalPath, minio.StatObjectOptions{})
+		if err == nil {
+			return fmt.Errorf("object already exists at %s", originalPath)
+		}
+		if minio.ToErrorResponse(err).Code != "NoSuchKey" {
+			return err
+		}
+	}
+
+	if _, err := c.CopyObject(ctx, originalPath, trashKey, minio.CopyDestOptions{}); err != nil {
+		return fmt.Errorf("failed to copy object from trash: %w", err)
+	}
+
+	if err := c.RemoveObject(ctx, trashKey, minio.RemoveObjectOptions{}); err != nil {
+		return fmt.Errorf("object restored but trash copy could not be removed: %w", err)
+	}
+
+	return nil
+}
+
+// ListTrash lists all soft-deleted objects
+func (c *Client) ListTrash(ctx context.Context) ([]TrashItem, error) {
+	var items []TrashItem
+	for objectInfo := range c.ListObjects(ctx, c.trashPrefix+"/", true) {
+		if objectInfo.Err != nil {
+			return nil, objectInfo.Err
+		}
+
+		originalPath, deletedAt, err := c.parseTrashKey(objectInfo.Key)
+		if err != nil {
+			// Skip objects that were not placed by TrashObject
+			continue
+		}
+
+		items = append(items, TrashItem{
+			TrashKey:     objectInfo.Key,
+			OriginalPath: originalPath,
+			DeletedAt:    deletedAt,
+			Size:         objectInfo.Size,
+		})
+	}
+
+	return items, nil
+}
+
+// EmptyTrash permanently removes trashed objects deleted more than olderThan ago
+// A zero olderThan removes everything in the trash
+func (c *Client) EmptyTrash(ctx context.Context, olderThan time.Duration) (RemoveResult, error) {
+	cutoff := time.Now().Add(-olderThan)
+
+	c.logDebug(ctx, "[MinIO] Emptying trash",
+		slog.String("bucket", c.bucketName),
+		slog.Time("cutoff", cutoff))
+
+	return c.RemoveObjectsByPrefix(ctx, c.trashPrefix+"/", func(objectInfo minio.ObjectInfo) bool {
+		if olderThan <= 0 {
+			return true
+		}
+		_, deletedAt, err := c.parseTrashKey(objectInfo.Key)
+		return err == nil && deletedAt.Before(cutoff)
+	}, RemoveOptions{ContinueOnError: true})
+}
+
+// isTrashKey reports whether a relative key is inside the trash prefix
+func (c *Client) isTrashKey(relativeKey string) bool {
+	return strings.HasPrefix(relativeKey, c.trashPrefix+"/")
+}
+
+// parseTrashKey extracts the original relative path and deletion time from a relative trash key
+func (c *Client) parseTrashKey(trashKey string) (string, time.Time, error) {
+	cleanKey := strings.Trim(trashKey, "/")
+	if !c.isTrashKey(cleanKey) {
+		return "", time.Time{}, fmt.Errorf("not a trash key: %s", trashKey)
+	}
+
+	timestamp, originalPath, found := strings.Cut(strings.TrimPrefix(cleanKey, c.trashPrefix+"/"), "/")
+	if !found || originalPath == "" {
+		return "", time.Time{}, fmt.Errorf("invalid trash key: %s", trashKey)
+	}
+
+	deletedAt, err := time.Parse(trashTimeLayout, timestamp)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("invalid trash key timestamp %s: %w", timestamp, err)
+	}
+
+	return originalPath, deletedAt, nil
+}
@@ -0,0 +1,162 @@
+package miniox
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+)
+
+// defaultMaxTreeEntries bounds the number of files and folders ListFolderTree collects by default
+const defaultMaxTreeEntries = 100000
+
+// ObjectEntry describes a file in a folder tree
+type ObjectEntry struct {
+	Name         string    // File name without its folder
+	RelativePath string    // Object path relative to the base directory prefix
+	Size         int64     // Size in bytes
+	ETag         string    // Entity tag
+	ContentType  string    // Content type, when reported by the listing
+	LastModified time.Time // Last modification time
+}
+
+// FolderNode is a folder in a tree built by ListFolderTree
+type FolderNode struct {
+	Name         string        // Folder name without its parent (empty for the root of the tree)
+	RelativePath string        // Folder path relative to the base directory prefix
+	Files        []ObjectEntry // Files directly in the folder, excluding folder markers
+	Children     []*FolderNode // Subfolders sorted by name
+}
+
+// TreeOptions configures ListFolderTreeWithOpts
+type TreeOptions struct {
+	MaxDepth   int // Number of folder levels below the root to include (unlimited when zero)
+	MaxEntries int // Maximum number of files and folders collected (default 100000)
+}
+
+// ErrTooManyEntries is returned when a folder tree exceeds the maximum number of entries
+type ErrTooManyEntries struct {
+	Prefix string // Relative prefix being listed
+	Limit  int    // Configured limit
+}
+
+// Error implements the error interface
+func (e *ErrTooManyEntries) Error() string {
+	return fmt.Sprintf("folder tree under %q exceeds the limit of %d entries", e.Prefix, e.Limit)
+}
+
+// ListFolderTree returns the folder hierarchy under a prefix, descending at most maxDepth levels (unlimited when zero)
+// The tree is built from a single recursive listing. Folder markers are left out of Files but make empty folders
+// appear in the tree
+func (c *Client) ListFolderTree(ctx context.Context, prefix string, maxDepth int) (*FolderNode, error) {
+	return c.ListFolderTreeWithOpts(ctx, prefix, TreeOptions{MaxDepth: maxDepth})
+}
+
+// ListFolderTreeWithOpts returns the folder hierarchy under a prefix with the given options
+// Returns *ErrTooManyEntries when the tree holds more entries than allowed
+func (c *Client) ListFolderTreeWithOpts(ctx context.Context, prefix string, opts TreeOptions) (root *FolderNode, err error) {
+	ctx, span := c.startSpan(ctx, "ListFolderTree", slog.String("prefix", prefix))
+	defer func() { span.End(err) }()
+
+	if err := c.validatePath(ctx, prefix); err != nil {
+		return nil, err
+	}
+	if opts.MaxDepth < 0 {
+		return nil, fmt.Errorf("max depth cannot be negative: %d", opts.MaxDepth)
+	}
+
+	maxEntries := opts.MaxEntries
+	if maxEntries <= 0 {
+		maxEntries = defaultMaxTreeEntries
+	}
+
+	fullPrefix := c.buildFolderPath(ctx, prefix)
+	rootPath := strings.TrimSuffix(c.stripBasePath(ctx, fullPrefix), "/")
+
+	c.logDebug(ctx, "[MinIO] Listing folder tree",
+		slog.String("bucket", c.bucketName),
+		slog.String("prefix", fullPrefix),
+		slog.Int("maxDepth", opts.MaxDepth))
+
+	// Stop the listing as soon as the entry limit is exceeded
+	listCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	root = &FolderNode{RelativePath: rootPath}
+	nodes := map[string]*FolderNode{"": root}
+	entries := 0
+
+	listOpts := minio.ListObjectsOptions{
+		Prefix:    fullPrefix,
+		Recursive: true,
+	}
+	for objectInfo := range c.minio.ListObjects(listCtx, c.bucketName, listOpts) {
+		if objectInfo.Err != nil {
+			return nil, objectInfo.Err
+		}
+
+		segments := strings.Split(strings.TrimPrefix(objectInfo.Key, fullPrefix), "/")
+		folders, fileName := segments[:len(segments)-1], segments[len(segments)-1]
+
+		truncated := opts.MaxDepth > 0 && len(folders) > opts.MaxDepth
+		if truncated {
+			folders = folders[:opts.MaxDepth]
+		}
+
+		// Materialize every folder on the way down, including folders that only hold a marker
+		parent := root
+		for i := range folders {
+			if folders[i] == "" {
+				break
+			}
+			key := strings.Join(folders[:i+1], "/")
+			node, ok := nodes[key]
+			if !ok {
+				if entries++; entries > maxEntries {
+					return nil, &ErrTooManyEntries{Prefix: prefix, Limit: maxEntries}
+				}
+				node = &FolderNode{Name: folders[i], RelativePath: joinRelative(rootPath, key)}
+				nodes[key] = node
+				parent.Children = append(parent.Children, node)
+			}
+			parent = node
+		}
+
+		if truncated || isFolderMarker(objectInfo.Key) {
+			continue
+		}
+
+		if entries++; entries > maxEntries {
+			return nil, &ErrTooManyEntries{Prefix: prefix, Limit: maxEntries}
+		}
+		parent.Files = append(parent.Files, ObjectEntry{
+			Name:         fileName,
+			RelativePath: c.stripBasePath(ctx, objectInfo.Key),
+			Size:         objectInfo.Size,
+			ETag:         objectInfo.ETag,
+			ContentType:  objectInfo.ContentType,
+			LastModified: objectInfo.LastModified,
+		})
+	}
+
+	// Keys such as "a-b/x" list before "a/x", so folders are sorted once the tree is complete
+	for _, node := range nodes {
+		slices.SortFunc(node.Children, func(a, b *FolderNode) int {
+			return strings.Compare(a.Name, b.Name)
+		})
+	}
+
+	return root, nil
+}
+
+// joinRelative joins a relative folder path and a path below it
+func joinRelative(base, rel string) string {
+	if base == "" {
+		return rel
+	}
+	return base + "/" + rel
+}
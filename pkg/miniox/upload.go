@@ -0,0 +1,323 @@
+package miniox
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"path"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/minio/minio-go/v7"
+)
+
+const (
+	// defaultMaxFilenameLength is the maximum length in bytes of a sanitized filename when none is configured
+	defaultMaxFilenameLength = 255
+
+	// originalFilenameMetadataKey is the user metadata key recording the uploaded filename
+	originalFilenameMetadataKey = "Original-Filename"
+)
+
+// MultipartUploadOptions configures PutObjectFromMultipart
+type MultipartUploadOptions struct {
+	UniqueKey         bool                   // Store as "<uuid><ext>" and keep the original name in user metadata
+	MaxFilenameLength int                    // Maximum length of the sanitized filename in bytes (default 255)
+	PutOptions        minio.PutObjectOptions // Additional upload options
+	Limits            *UploadLimits          // Overrides the client upload limits when set
+}
+
+// UploadResult describes an object stored by PutObjectFromMultipart, PutObjectWithGeneratedKey or PutObjectWithChecksum
+// Checksums are base64 encoded and only set when the upload carried that checksum (see Config.EnableChecksums);
+// for multipart uploads they are checksums of the part checksums unless a full-object checksum was requested
+type UploadResult struct {
+	Key            string // Relative object path
+	OriginalName   string // Sanitized original filename
+	ContentType    string // Stored content type
+	Size           int64  // Object size in bytes
+	ETag           string // Object ETag
+	PublicURL      string // Public URL, empty when no public URL is configured
+	VersionID      string // Version ID, empty when versioning is disabled
+	ChecksumCRC32C string // CRC32C checksum computed by the server
+	ChecksumSHA256 string // SHA-256 checksum computed by the server
+	ChecksumMode   string // "COMPOSITE" or "FULL_OBJECT" when a checksum is set
+}
+
+// PutObjectFromMultipart uploads a file received in a multipart form into a folder
+// The content type is taken from the part header, falling back to detection when it is missing or generic.
+// The original filename is sanitized (path components and control characters removed, length limited) and
+// used as the object name unless opts.UniqueKey is set
+func (c *Client) PutObjectFromMultipart(ctx context.Context, destFolder string, fh *multipart.FileHeader, opts MultipartUploadOptions) (UploadResult, error) {
+	if fh == nil {
+		return UploadResult{}, fmt.Errorf("file header cannot be nil")
+	}
+	if err := c.validatePath(ctx, destFolder); err != nil {
+		return UploadResult{}, err
+	}
+
+	maxLength := opts.MaxFilenameLength
+	if maxLength <= 0 {
+		maxLength = defaultMaxFilenameLength
+	}
+	originalName := SanitizeFilename(fh.Filename, maxLength)
+
+	objectName := originalName
+	putOpts := opts.PutOptions
+	if opts.UniqueKey {
+		objectName = newUUID() + strings.ToLower(path.Ext(originalName))
+
+		userMetadata := make(map[string]string, len(putOpts.UserMetadata)+1)
+		for key, value := range putOpts.UserMetadata {
+			userMetadata[key] = value
+		}
+		// Metadata values must be ASCII; other names are RFC 2047 encoded
+		userMetadata[originalFilenameMetadataKey] = mime.QEncoding.Encode("utf-8", originalName)
+		putOpts.UserMetadata = userMetadata
+	}
+
+	objectPath := objectName
+	if cleanFolder := strings.Trim(toSlash(destFolder), "/"); cleanFolder != "" {
+		objectPath = cleanFolder + "/" + objectName
+	}
+
+	file, err := fh.Open()
+	if err != nil {
+		return UploadResult{}, fmt.Errorf("failed to open uploaded file: %w", err)
+	}
+	defer file.Close()
+
+	var reader io.Reader = file
+	if putOpts.ContentType == "" {
+		putOpts.ContentType = fh.Header.Get("Content-Type")
+	}
+	if putOpts.ContentType == "" || putOpts.ContentType == "application/octet-stream" {
+		contentType, detectedReader, err := detectContentType(originalName, reader, fh.Size)
+		if err != nil {
+			return UploadResult{}, fmt.Errorf("failed to detect content type: %w", err)
+		}
+		putOpts.ContentType = contentType
+		reader = detectedReader
+	}
+
+	limits := c.uploadLimits
+	if opts.Limits != nil {
+		limits = *opts.Limits
+	}
+
+	uploadInfo, err := c.putObject(ctx, objectPath, reader, fh.Size, putOpts, limits)
+	if err != nil {
+		return UploadResult{}, err
+	}
+
+	return c.uploadResult(ctx, uploadInfo, originalName, putOpts.ContentType), nil
+}
+
+// uploadResult describes a completed upload, including the public URL when one is configured
+func (c *Client) uploadResult(ctx context.Context, uploadInfo minio.UploadInfo, originalName string, contentType string) UploadResult {
+	result := UploadResult{
+		Key:            uploadInfo.Key,
+		OriginalName:   originalName,
+		ContentType:    contentType,
+		Size:           uploadInfo.Size,
+		ETag:           uploadInfo.ETag,
+		VersionID:      uploadInfo.VersionID,
+		ChecksumCRC32C: uploadInfo.ChecksumCRC32C,
+		ChecksumSHA256: uploadInfo.ChecksumSHA256,
+		ChecksumMode:   uploadInfo.ChecksumMode,
+	}
+	if c.publicBaseURL != "" {
+		if publicURL, err := c.publicURL(ctx, uploadInfo.Key); err == nil {
+			result.PublicURL = publicURL.String()
+		}
+	}
+	return result
+}
+
+// PutObjectWithChecksum uploads an object with a checksum of the given type and reports the stored checksums
+// Use minio.ChecksumSHA256 or minio.ChecksumCRC32C, or minio.ChecksumFullObjectCRC32C to get a whole-object CRC32C
+// for multipart uploads too. The server verifies the checksum, so a corrupted upload fails. Requires
+// Config.EnableChecksums
+func (c *Client) PutObjectWithChecksum(ctx context.Context, objectPath string, reader io.Reader, objectSize int64, opts minio.PutObjectOptions, checksum minio.ChecksumType) (UploadResult, error) {
+	if !c.checksums {
+		return UploadResult{}, fmt.Errorf("upload checksums require Config.EnableChecksums")
+	}
+	if !checksum.IsSet() {
+		return UploadResult{}, fmt.Errorf("checksum type is required")
+	}
+
+	opts.Checksum = checksum
+	uploadInfo, err := c.PutObject(ctx, objectPath, reader, objectSize, opts)
+	if err != nil {
+		return UploadResult{}, err
+	}
+
+	return c.uploadResult(ctx, uploadInfo, "", opts.ContentType), nil
+}
+
+// SanitizeFilename makes a user-supplied filename safe to use as an object name
+// Directory components (with either slash style), control characters and ".." sequences are removed and the
+// result is truncated to maxLength bytes, keeping the extension. An unusable name becomes "file"
+func SanitizeFilename(name string, maxLength int) string {
+	name = strings.ReplaceAll(name, "\\", "/")
+	name = name[strings.LastIndex(name, "/")+1:]
+
+	name = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) || r == utf8.RuneError {
+			return -1
+		}
+		return r
+	}, name)
+
+	for strings.Contains(name, "..") {
+		name = strings.ReplaceAll(name, "..", ".")
+	}
+	name = strings.TrimSpace(name)
+
+	if name == "" || name == "." {
+		name = "file"
+	}
+
+	if maxLength > 0 && len(name) > maxLength {
+		ext := path.Ext(name)
+		if len(ext) >= maxLength {
+			ext = ""
+		}
+		base := name[:maxLength-len(ext)]
+		// Do not cut a multi-byte character in half
+		for !utf8.ValidString(base) {
+			base = base[:len(base)-1]
+		}
+		name = base + ext
+	}
+
+	return name
+}
+
+// newUUID returns a random (version 4) UUID string
+func newUUID() string {
+	var b [16]byte
+	rand.Read(b[:]) // Never fails
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+
+	h := hex.EncodeToString(b[:])
+	return h[0:8] + "-" + h[8:12] + "-" + h[12:16] + "-" + h[16:20] + "-" + h[20:32]
+}
+
+// KeyGenerator builds the object key, relative to the target folder, for PutObjectWithGeneratedKey
+type KeyGenerator func(opts GeneratedKeyOptions) string
+
+// GeneratedKeyOptions configures PutObjectWithGeneratedKey
+type GeneratedKeyOptions struct {
+	OriginalName        string                 // Original filename, used to infer the extension and content type
+	Extension           string                 // Extension to use (e.g. ".png"); inferred when empty
+	NoExtension         bool                   // Generate keys without an extension
+	DisableDateSharding bool                   // Omit the yyyy/mm/dd folders
+	Time                time.Time              // Time used for date sharding (default now, UTC)
+	PutOptions          minio.PutObjectOptions // Additional upload options
+}
+
+// preferredExtensions maps common content types to their usual extension
+// mime.ExtensionsByType returns alternatives in alphabetical order (".jfif" before ".jpg")
+var preferredExtensions = map[string]string{
+	"image/jpeg":       ".jpg",
+	"image/png":        ".png",
+	"image/gif":        ".gif",
+	"image/webp":       ".webp",
+	"image/svg+xml":    ".svg",
+	"application/pdf":  ".pdf",
+	"application/json": ".json",
+	"application/zip":  ".zip",
+	"text/plain":       ".txt",
+	"text/csv":         ".csv",
+	"text/html":        ".html",
+	"video/mp4":        ".mp4",
+	"audio/mpeg":       ".mp3",
+}
+
+// PutObjectWithGeneratedKey uploads an object under a collision-free generated key inside a folder
+// By default the key is "<folder>/<yyyy>/<mm>/<dd>/<uuid><ext>", where the extension comes from opts.Extension,
+// the original name or the content type. Config.KeyGenerator replaces the scheme below the folder
+func (c *Client) PutObjectWithGeneratedKey(ctx context.Context, folder string, reader io.Reader, objectSize int64, opts GeneratedKeyOptions) (UploadResult, error) {
+	if err := c.validatePath(ctx, folder); err != nil {
+		return UploadResult{}, err
+	}
+
+	originalName := ""
+	if opts.OriginalName != "" {
+		originalName = SanitizeFilename(opts.OriginalName, defaultMaxFilenameLength)
+		opts.OriginalName = originalName
+	}
+
+	putOpts := opts.PutOptions
+	if putOpts.ContentType == "" && originalName != "" {
+		putOpts.ContentType = mime.TypeByExtension(path.Ext(originalName))
+	}
+	if opts.Extension == "" && !opts.NoExtension {
+		opts.Extension = inferExtension(originalName, putOpts.ContentType)
+	}
+	if opts.Time.IsZero() {
+		opts.Time = time.Now().UTC()
+	}
+
+	generate := c.keyGenerator
+	if generate == nil {
+		generate = defaultKeyGenerator
+	}
+	key := strings.Trim(generate(opts), "/")
+	if key == "" {
+		return UploadResult{}, fmt.Errorf("key generator returned an empty key")
+	}
+
+	objectPath := key
+	if cleanFolder := strings.Trim(toSlash(folder), "/"); cleanFolder != "" {
+		objectPath = cleanFolder + "/" + key
+	}
+	if err := c.validatePath(ctx, objectPath); err != nil {
+		return UploadResult{}, fmt.Errorf("invalid generated key: %w", err)
+	}
+
+	uploadInfo, err := c.PutObject(ctx, objectPath, reader, objectSize, putOpts)
+	if err != nil {
+		return UploadResult{}, err
+	}
+
+	return c.uploadResult(ctx, uploadInfo, originalName, putOpts.ContentType), nil
+}
+
+// defaultKeyGenerator builds "<yyyy>/<mm>/<dd>/<uuid><ext>" keys
+func defaultKeyGenerator(opts GeneratedKeyOptions) string {
+	name := newUUID()
+	if !opts.NoExtension {
+		name += opts.Extension
+	}
+	if opts.DisableDateSharding {
+		return name
+	}
+	return opts.Time.Format("2006/01/02") + "/" + name
+}
+
+// inferExtension picks an extension from the original filename, falling back to the content type
+func inferExtension(originalName string, contentType string) string {
+	if ext := strings.ToLower(path.Ext(originalName)); ext != "" && ext != "." {
+		return ext
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return ""
+	}
+	if ext, ok := preferredExtensions[mediaType]; ok {
+		return ext
+	}
+	if extensions, err := mime.ExtensionsByType(mediaType); err == nil && len(extensions) > 0 {
+		return extensions[0]
+	}
+	return ""
+}
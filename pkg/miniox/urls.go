@@ -3,113 +3,316 @@ package miniox
 import (
 	"context"
 	"fmt"
+	"html"
 	"log/slog"
+	"maps"
+	"net/http"
 	"net/url"
+	"slices"
 	"strings"
 	"time"
 
-	"github.com/aeternitas-infinita/rmlog"
 	"github.com/minio/minio-go/v7"
 )
 
 // GetPresignedURL generates a presigned URL for GET operation with automatic path prefix handling
 func (c *Client) GetPresignedURL(ctx context.Context, objectPath string, expiry time.Duration) (*url.URL, error) {
-	if err := c.ValidatePath(objectPath); err != nil {
+	if err := c.validatePath(ctx, objectPath); err != nil {
 		return nil, err
 	}
 
-	fullPath := c.buildPath(objectPath)
+	fullPath := c.buildPath(ctx, objectPath)
 
-	rmlog.DebugCtxMin(ctx, "[MinIO] Generating presigned GET URL",
+	c.logDebug(ctx, "[MinIO] Generating presigned GET URL",
 		slog.String("bucket", c.bucketName),
 		slog.String("object", fullPath),
 		slog.Duration("expiry", expiry))
 
-	return c.minio.PresignedGetObject(ctx, c.bucketName, fullPath, expiry, nil)
+	return withRetry(ctx, c, "PresignedGetObject", func() (*url.URL, error) {
+		return c.minio.PresignedGetObject(ctx, c.bucketName, fullPath, expiry, nil)
+	})
+}
+
+// GetPresignedURLs generates presigned GET URLs for multiple objects with automatic path prefix handling
+// Results and per-path errors are keyed by the relative object path as passed in.
+// Presigning is local once the bucket location is cached, so paths are signed sequentially.
+func (c *Client) GetPresignedURLs(ctx context.Context, objectPaths []string, expiry time.Duration) (map[string]*url.URL, map[string]error) {
+	c.logDebug(ctx, "[MinIO] Generating presigned GET URLs",
+		slog.String("bucket", c.bucketName),
+		slog.Int("count", len(objectPaths)),
+		slog.Duration("expiry", expiry))
+
+	urls := make(map[string]*url.URL, len(objectPaths))
+	errs := make(map[string]error)
+
+	for _, objectPath := range objectPaths {
+		if err := c.validatePath(ctx, objectPath); err != nil {
+			errs[objectPath] = err
+			continue
+		}
+
+		fullPath := c.buildPath(ctx, objectPath)
+		presignedURL, err := withRetry(ctx, c, "PresignedGetObject", func() (*url.URL, error) {
+			return c.minio.PresignedGetObject(ctx, c.bucketName, fullPath, expiry, nil)
+		})
+		if err != nil {
+			errs[objectPath] = err
+			continue
+		}
+		urls[objectPath] = presignedURL
+	}
+
+	return urls, errs
 }
 
 // GetPresignedURLWithParams generates a presigned URL for GET operation with custom parameters
 func (c *Client) GetPresignedURLWithParams(ctx context.Context, objectPath string, expiry time.Duration, reqParams url.Values) (*url.URL, error) {
-	if err := c.ValidatePath(objectPath); err != nil {
+	if err := c.validatePath(ctx, objectPath); err != nil {
 		return nil, err
 	}
 
-	fullPath := c.buildPath(objectPath)
+	fullPath := c.buildPath(ctx, objectPath)
 
-	rmlog.DebugCtxMin(ctx, "[MinIO] Generating presigned GET URL with params",
+	c.logDebug(ctx, "[MinIO] Generating presigned GET URL with params",
 		slog.String("bucket", c.bucketName),
 		slog.String("object", fullPath),
 		slog.Duration("expiry", expiry))
 
-	return c.minio.PresignedGetObject(ctx, c.bucketName, fullPath, expiry, reqParams)
+	return withRetry(ctx, c, "PresignedGetObject", func() (*url.URL, error) {
+		return c.minio.PresignedGetObject(ctx, c.bucketName, fullPath, expiry, reqParams)
+	})
+}
+
+// allowedResponseHeaders maps supported response header overrides to their presigned query parameters
+var allowedResponseHeaders = map[string]string{
+	"content-type":        "response-content-type",
+	"content-disposition": "response-content-disposition",
+	"cache-control":       "response-cache-control",
+}
+
+// GetPresignedURLWithResponseHeaders generates a presigned URL for GET operation that overrides response headers
+// Supported header keys are content-type, content-disposition and cache-control (case-insensitive)
+func (c *Client) GetPresignedURLWithResponseHeaders(ctx context.Context, objectPath string, expiry time.Duration, headers map[string]string) (*url.URL, error) {
+	if err := c.validatePath(ctx, objectPath); err != nil {
+		return nil, err
+	}
+
+	reqParams := make(url.Values, len(headers))
+	for key, value := range headers {
+		param, ok := allowedResponseHeaders[strings.ToLower(strings.TrimSpace(key))]
+		if !ok {
+			return nil, fmt.Errorf("unsupported response header override: %s", key)
+		}
+		reqParams.Set(param, value)
+	}
+
+	fullPath := c.buildPath(ctx, objectPath)
+
+	c.logDebug(ctx, "[MinIO] Generating presigned GET URL with response headers",
+		slog.String("bucket", c.bucketName),
+		slog.String("object", fullPath),
+		slog.Duration("expiry", expiry))
+
+	return withRetry(ctx, c, "PresignedGetObject", func() (*url.URL, error) {
+		return c.minio.PresignedGetObject(ctx, c.bucketName, fullPath, expiry, reqParams)
+	})
 }
 
 // GetPresignedPutURL generates a presigned URL for PUT operation with automatic path prefix handling
 func (c *Client) GetPresignedPutURL(ctx context.Context, objectPath string, expiry time.Duration) (*url.URL, error) {
-	if err := c.ValidatePath(objectPath); err != nil {
+	if err := c.validatePath(ctx, objectPath); err != nil {
 		return nil, err
 	}
 
-	fullPath := c.buildPath(objectPath)
+	fullPath := c.buildPath(ctx, objectPath)
 
-	rmlog.DebugCtxMin(ctx, "[MinIO] Generating presigned PUT URL",
+	c.logDebug(ctx, "[MinIO] Generating presigned PUT URL",
 		slog.String("bucket", c.bucketName),
 		slog.String("object", fullPath),
 		slog.Duration("expiry", expiry))
 
-	return c.minio.PresignedPutObject(ctx, c.bucketName, fullPath, expiry)
+	return withRetry(ctx, c, "PresignedPutObject", func() (*url.URL, error) {
+		return c.minio.PresignedPutObject(ctx, c.bucketName, fullPath, expiry)
+	})
+}
+
+// presignableMethods are the HTTP methods MinIO accepts on presigned object URLs
+var presignableMethods = []string{http.MethodGet, http.MethodHead, http.MethodPut, http.MethodPost, http.MethodDelete}
+
+// PresignMethod generates a presigned URL for any supported HTTP method with automatic path prefix handling
+// The method must be GET, HEAD, PUT, POST or DELETE (case-insensitive); reqParams may be nil
+func (c *Client) PresignMethod(ctx context.Context, method string, objectPath string, expiry time.Duration, reqParams url.Values) (*url.URL, error) {
+	return c.presign(ctx, method, objectPath, expiry, reqParams, nil)
+}
+
+// PresignRequest generates a presigned URL for any supported HTTP method, signing the given headers
+// The request made with the URL must send the same header values. See PresignMethod for the supported methods
+func (c *Client) PresignRequest(ctx context.Context, method string, objectPath string, expiry time.Duration, headers http.Header) (*url.URL, error) {
+	return c.presign(ctx, method, objectPath, expiry, nil, headers)
+}
+
+// presign generates a presigned URL with optional query parameters and signed headers
+func (c *Client) presign(ctx context.Context, method string, objectPath string, expiry time.Duration, reqParams url.Values, headers http.Header) (*url.URL, error) {
+	if err := c.validatePath(ctx, objectPath); err != nil {
+		return nil, err
+	}
+
+	method = strings.ToUpper(strings.TrimSpace(method))
+	if !slices.Contains(presignableMethods, method) {
+		return nil, fmt.Errorf("unsupported presign method %q: must be one of %s", method, strings.Join(presignableMethods, ", "))
+	}
+
+	fullPath := c.buildPath(ctx, objectPath)
+
+	c.logDebug(ctx, "[MinIO] Generating presigned URL",
+		slog.String("bucket", c.bucketName),
+		slog.String("object", fullPath),
+		slog.String("method", method),
+		slog.Duration("expiry", expiry))
+
+	return withRetry(ctx, c, "Presign", func() (*url.URL, error) {
+		return c.minio.PresignHeader(ctx, method, c.bucketName, fullPath, expiry, reqParams, headers)
+	})
 }
 
 // GetPresignedPostPolicy generates a presigned POST policy with automatic path prefix handling
 func (c *Client) GetPresignedPostPolicy(ctx context.Context, policy *minio.PostPolicy) (*url.URL, map[string]string, error) {
-	rmlog.DebugCtxMin(ctx, "[MinIO] Generating presigned POST policy",
+	c.logDebug(ctx, "[MinIO] Generating presigned POST policy",
 		slog.String("bucket", c.bucketName))
 
 	// Note: PostPolicy object key should be set with prefix applied before calling this method
-	return c.minio.PresignedPostPolicy(ctx, policy)
+	return c.presignPostPolicy(ctx, policy)
 }
 
 // GetPublicURL generates a public URL for an object (requires public bucket or appropriate policy)
+// It takes no context, so a prefix set with WithContextPrefix does not apply; use a client from WithPrefix instead
 func (c *Client) GetPublicURL(objectPath string) (*url.URL, error) {
+	return c.publicURL(context.Background(), objectPath)
+}
+
+// publicURL generates the public URL for an object resolved with the context prefix of ctx
+func (c *Client) publicURL(ctx context.Context, objectPath string) (*url.URL, error) {
 	if c.publicBaseURL == "" {
 		return nil, fmt.Errorf("public base URL not configured")
 	}
 
-	if err := c.ValidatePath(objectPath); err != nil {
+	if err := c.validatePath(ctx, objectPath); err != nil {
 		return nil, err
 	}
 
-	fullPath := c.buildPath(objectPath)
+	fullPath := c.buildPath(ctx, objectPath)
+
+	baseURL, err := url.Parse(strings.TrimSuffix(c.publicBaseURL, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid public base URL: %w", err)
+	}
 
-	// Clean up the public base URL
-	baseURL := strings.TrimSuffix(c.publicBaseURL, "/")
+	// Set the unescaped path so characters such as "#", "?" and "%" in keys are escaped by the URL
+	baseURL.Path = baseURL.Path + "/" + c.bucketName + "/" + fullPath
+	baseURL.RawPath = ""
+	return baseURL, nil
+}
 
-	// Construct the full public URL
-	publicURLString := fmt.Sprintf("%s/%s/%s", baseURL, c.bucketName, fullPath)
+// ErrForeignObjectURL is returned by ParseObjectPath when a URL does not address an object of this client
+type ErrForeignObjectURL struct {
+	URL    string // URL that was parsed
+	Bucket string // Bucket addressed by the URL, empty when the host is not recognized
+	Reason string // Why the URL does not belong to the client
+}
 
-	return url.Parse(publicURLString)
+// Error implements the error interface
+func (e *ErrForeignObjectURL) Error() string {
+	return fmt.Sprintf("URL %s does not address an object of this client: %s", e.URL, e.Reason)
 }
 
-// ComposeObject composes an object from existing objects with automatic path prefix handling
-func (c *Client) ComposeObject(ctx context.Context, destObjectPath string, srcObjects []minio.CopySrcOptions, opts minio.CopyDestOptions) (minio.UploadInfo, error) {
-	if err := c.ValidatePath(destObjectPath); err != nil {
-		return minio.UploadInfo{}, err
+// ParseObjectPath maps a URL back to the relative object path it addresses
+// Accepted forms are public URLs as built by GetPublicURL, presigned URLs against the configured endpoint
+// (path-style or virtual-hosted-style, the query string is ignored) and bare "bucket/key" paths. Path segments
+// are URL-decoded. Returns *ErrForeignObjectURL when the URL points at another host or bucket or outside the
+// base directory prefix. It takes no context, so a prefix set with WithContextPrefix stays part of the returned path
+func (c *Client) ParseObjectPath(rawURL string) (string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid object URL: %w", err)
 	}
 
-	fullDestPath := c.buildPath(destObjectPath)
+	bucket, key, ok := c.splitObjectURL(u)
+	if !ok {
+		return "", &ErrForeignObjectURL{URL: rawURL, Reason: "host matches neither the public URL nor the endpoint"}
+	}
+	if bucket != c.bucketName {
+		return "", &ErrForeignObjectURL{URL: rawURL, Bucket: bucket, Reason: fmt.Sprintf("bucket %s is not %s", bucket, c.bucketName)}
+	}
 
-	// Apply prefix to source objects if they are from the same bucket
-	for i := range srcObjects {
-		if srcObjects[i].Bucket == c.bucketName {
-			// Validate source object path
-			if err := c.ValidatePath(srcObjects[i].Object); err != nil {
-				return minio.UploadInfo{}, fmt.Errorf("invalid source object path %s: %w", srcObjects[i].Object, err)
+	key = cleanKeyPath(key)
+	if prefix := cleanKeyPath(c.baseDirPrefix); prefix != "" && key != prefix && !strings.HasPrefix(key, prefix+"/") {
+		return "", &ErrForeignObjectURL{URL: rawURL, Bucket: bucket, Reason: fmt.Sprintf("key is outside the base directory prefix %s", prefix)}
+	}
+
+	objectPath := c.stripBasePath(context.Background(), key)
+	if objectPath == "" {
+		return "", fmt.Errorf("URL %s does not address an object", rawURL)
+	}
+	if err := c.ValidatePath(objectPath); err != nil {
+		return "", err
+	}
+	return objectPath, nil
+}
+
+// splitObjectURL returns the bucket and the decoded full key addressed by a parsed URL
+// ok is false when the URL has a host that is neither the public URL host nor the endpoint
+func (c *Client) splitObjectURL(u *url.URL) (bucket string, key string, ok bool) {
+	splitPath := func(p string) (string, string, bool) {
+		bucket, key, _ := strings.Cut(strings.TrimPrefix(p, "/"), "/")
+		return bucket, key, true
+	}
+
+	// Bare "bucket/key" path
+	if u.Scheme == "" && u.Host == "" {
+		return splitPath(u.Path)
+	}
+
+	if c.publicBaseURL != "" {
+		if base, err := url.Parse(strings.TrimSuffix(c.publicBaseURL, "/")); err == nil && strings.EqualFold(u.Host, base.Host) {
+			if rest, found := strings.CutPrefix(u.Path, base.Path+"/"); found {
+				return splitPath(rest)
 			}
-			srcObjects[i].Object = c.buildPath(srcObjects[i].Object)
 		}
 	}
 
-	rmlog.DebugCtxMin(ctx, "[MinIO] Composing object",
+	endpoint := c.minio.EndpointURL()
+	host := strings.ToLower(u.Host)
+	endpointHost := strings.ToLower(endpoint.Host)
+	switch {
+	case host == endpointHost:
+		return splitPath(u.Path)
+	case strings.HasSuffix(host, "."+endpointHost):
+		// Virtual-hosted-style: the bucket is the first host label
+		return strings.TrimSuffix(host, "."+endpointHost), strings.TrimPrefix(u.Path, "/"), true
+	}
+	return "", "", false
+}
+
+// ComposeObject composes an object from existing objects with automatic path prefix handling
+// Sources without a bucket default to the configured bucket; sources from other buckets are used as-is
+func (c *Client) ComposeObject(ctx context.Context, destObjectPath string, srcObjects []minio.CopySrcOptions, opts minio.CopyDestOptions) (uploadInfo minio.UploadInfo, err error) {
+	ctx, span := c.startOperation(ctx, "ComposeObject",
+		slog.String("dest", destObjectPath),
+		slog.Int("sources", len(srcObjects)))
+	defer func() { span.End(err) }()
+
+	if err := c.validatePath(ctx, destObjectPath); err != nil {
+		return minio.UploadInfo{}, err
+	}
+
+	fullDestPath := c.buildPath(ctx, destObjectPath)
+
+	srcObjects, err = c.composeSources(ctx, srcObjects)
+	if err != nil {
+		return minio.UploadInfo{}, err
+	}
+
+	c.logDebug(ctx, "[MinIO] Composing object",
 		slog.String("bucket", c.bucketName),
 		slog.String("dest", fullDestPath),
 		slog.Int("sources", len(srcObjects)))
@@ -117,17 +320,40 @@ func (c *Client) ComposeObject(ctx context.Context, destObjectPath string, srcOb
 	// Set the destination in the opts
 	opts.Bucket = c.bucketName
 	opts.Object = fullDestPath
+	opts.Encryption = c.writeSSE(opts.Encryption)
 
-	uploadInfo, err := c.minio.ComposeObject(ctx, opts, srcObjects...)
+	defer c.invalidateFullPath(fullDestPath)
+	uploadInfo, err = c.minio.ComposeObject(ctx, opts, srcObjects...)
 	if err != nil {
 		return uploadInfo, err
 	}
 
 	// Strip base path from returned upload info
-	uploadInfo.Key = c.stripBasePath(uploadInfo.Key)
+	uploadInfo.Key = c.stripBasePath(ctx, uploadInfo.Key)
 	return uploadInfo, nil
 }
 
+// composeSources returns a copy of the compose sources with the configured bucket and prefix applied
+// An empty bucket means the configured one; sources from other buckets are used as-is. The caller's slice is
+// left untouched so it can be reused, e.g. for a retry, without applying the prefix twice
+func (c *Client) composeSources(ctx context.Context, srcObjects []minio.CopySrcOptions) ([]minio.CopySrcOptions, error) {
+	sources := slices.Clone(srcObjects)
+	for i := range sources {
+		if sources[i].Bucket == "" {
+			sources[i].Bucket = c.bucketName
+		}
+		if sources[i].Bucket == c.bucketName {
+			// Validate source object path
+			if err := c.validatePath(ctx, sources[i].Object); err != nil {
+				return nil, fmt.Errorf("invalid source object path %s: %w", sources[i].Object, err)
+			}
+			sources[i].Object = c.buildPath(ctx, sources[i].Object)
+			sources[i].Encryption = c.readSSE(sources[i].Encryption)
+		}
+	}
+	return sources, nil
+}
+
 // PresignedGetObject generates a presigned URL for GET operation with automatic path prefix handling
 // This is an alias for GetPresignedURL for consistency with MinIO naming
 func (c *Client) PresignedGetObject(ctx context.Context, objectPath string, expiry time.Duration, reqParams url.Values) (*url.URL, error) {
@@ -140,31 +366,39 @@ func (c *Client) PresignedPutObject(ctx context.Context, objectPath string, expi
 	return c.GetPresignedPutURL(ctx, objectPath, expiry)
 }
 
+// PresignedDeleteObject generates a presigned URL for DELETE operation with automatic path prefix handling
+// Anyone holding the URL can delete the object until it expires
+func (c *Client) PresignedDeleteObject(ctx context.Context, objectPath string, expiry time.Duration) (*url.URL, error) {
+	return c.PresignMethod(ctx, http.MethodDelete, objectPath, expiry, nil)
+}
+
 // PresignedHeadObject generates a presigned URL for HEAD operation with automatic path prefix handling
 func (c *Client) PresignedHeadObject(ctx context.Context, objectPath string, expiry time.Duration, reqParams url.Values) (*url.URL, error) {
-	if err := c.ValidatePath(objectPath); err != nil {
+	if err := c.validatePath(ctx, objectPath); err != nil {
 		return nil, err
 	}
 
-	fullPath := c.buildPath(objectPath)
+	fullPath := c.buildPath(ctx, objectPath)
 
-	rmlog.DebugCtxMin(ctx, "[MinIO] Generating presigned HEAD URL",
+	c.logDebug(ctx, "[MinIO] Generating presigned HEAD URL",
 		slog.String("bucket", c.bucketName),
 		slog.String("object", fullPath),
 		slog.Duration("expiry", expiry))
 
-	return c.minio.PresignedHeadObject(ctx, c.bucketName, fullPath, expiry, reqParams)
+	return withRetry(ctx, c, "PresignedHeadObject", func() (*url.URL, error) {
+		return c.minio.PresignedHeadObject(ctx, c.bucketName, fullPath, expiry, reqParams)
+	})
 }
 
 // PresignedPostPolicyForUpload creates a presigned POST policy for browser-based uploads
 func (c *Client) PresignedPostPolicyForUpload(ctx context.Context, objectPath string, expiry time.Duration, maxSize int64) (*url.URL, map[string]string, error) {
-	if err := c.ValidatePath(objectPath); err != nil {
+	if err := c.validatePath(ctx, objectPath); err != nil {
 		return nil, nil, err
 	}
 
-	fullPath := c.buildPath(objectPath)
+	fullPath := c.buildPath(ctx, objectPath)
 
-	rmlog.DebugCtxMin(ctx, "[MinIO] Generating presigned POST policy for upload",
+	c.logDebug(ctx, "[MinIO] Generating presigned POST policy for upload",
 		slog.String("bucket", c.bucketName),
 		slog.String("object", fullPath),
 		slog.Duration("expiry", expiry),
@@ -180,18 +414,18 @@ func (c *Client) PresignedPostPolicyForUpload(ctx context.Context, objectPath st
 		policy.SetContentLengthRange(1, maxSize)
 	}
 
-	return c.minio.PresignedPostPolicy(ctx, policy)
+	return c.presignPostPolicy(ctx, policy)
 }
 
 // PresignedPostPolicyWithConditions creates a presigned POST policy with custom conditions
 func (c *Client) PresignedPostPolicyWithConditions(ctx context.Context, objectPath string, expiry time.Duration, contentType string, maxSize int64) (*url.URL, map[string]string, error) {
-	if err := c.ValidatePath(objectPath); err != nil {
+	if err := c.validatePath(ctx, objectPath); err != nil {
 		return nil, nil, err
 	}
 
-	fullPath := c.buildPath(objectPath)
+	fullPath := c.buildPath(ctx, objectPath)
 
-	rmlog.DebugCtxMin(ctx, "[MinIO] Generating presigned POST policy with conditions",
+	c.logDebug(ctx, "[MinIO] Generating presigned POST policy with conditions",
 		slog.String("bucket", c.bucketName),
 		slog.String("object", fullPath),
 		slog.Duration("expiry", expiry),
@@ -213,5 +447,103 @@ func (c *Client) PresignedPostPolicyWithConditions(ctx context.Context, objectPa
 		policy.SetContentLengthRange(1, maxSize)
 	}
 
-	return c.minio.PresignedPostPolicy(ctx, policy)
+	return c.presignPostPolicy(ctx, policy)
+}
+
+// PostFormOptions configures BuildPostForm
+type PostFormOptions struct {
+	Expiry                time.Duration // How long the form can be used
+	MaxSize               int64         // Maximum upload size in bytes (unlimited when zero)
+	ContentType           string        // Required content type; a value ending in "/" (e.g. "image/") only requires that prefix
+	SuccessActionRedirect string        // URL the browser is redirected to after a successful upload
+}
+
+// PostForm is a presigned browser upload form that can be serialized to JSON for frontends
+// The file must be sent as the last multipart field, named "file"
+type PostForm struct {
+	URL    string            `json:"url"`    // Form action URL
+	Fields map[string]string `json:"fields"` // Form fields to send along with the file
+}
+
+// HTMLFields renders the form fields as hidden HTML inputs in a stable order
+func (f *PostForm) HTMLFields() string {
+	var sb strings.Builder
+	for _, name := range slices.Sorted(maps.Keys(f.Fields)) {
+		fmt.Fprintf(&sb, "<input type=\"hidden\" name=\"%s\" value=\"%s\">\n",
+			html.EscapeString(name), html.EscapeString(f.Fields[name]))
+	}
+	return sb.String()
+}
+
+// BuildPostForm creates a presigned POST form for a browser upload with automatic path prefix handling
+func (c *Client) BuildPostForm(ctx context.Context, objectPath string, opts PostFormOptions) (*PostForm, error) {
+	if err := c.validatePath(ctx, objectPath); err != nil {
+		return nil, err
+	}
+	if opts.Expiry <= 0 {
+		return nil, fmt.Errorf("expiry must be positive")
+	}
+
+	fullPath := c.buildPath(ctx, objectPath)
+
+	c.logDebug(ctx, "[MinIO] Building presigned POST form",
+		slog.String("bucket", c.bucketName),
+		slog.String("object", fullPath),
+		slog.Duration("expiry", opts.Expiry),
+		slog.Int64("maxSize", opts.MaxSize),
+		slog.String("contentType", opts.ContentType))
+
+	policy := minio.NewPostPolicy()
+	if err := policy.SetBucket(c.bucketName); err != nil {
+		return nil, err
+	}
+	if err := policy.SetKey(fullPath); err != nil {
+		return nil, err
+	}
+	if err := policy.SetExpires(time.Now().Add(opts.Expiry)); err != nil {
+		return nil, err
+	}
+
+	if strings.HasSuffix(opts.ContentType, "/") {
+		if err := policy.SetContentTypeStartsWith(opts.ContentType); err != nil {
+			return nil, err
+		}
+	} else if opts.ContentType != "" {
+		if err := policy.SetContentType(opts.ContentType); err != nil {
+			return nil, err
+		}
+	}
+
+	if opts.MaxSize > 0 {
+		if err := policy.SetContentLengthRange(1, opts.MaxSize); err != nil {
+			return nil, err
+		}
+	}
+
+	if opts.SuccessActionRedirect != "" {
+		if err := policy.SetSuccessActionRedirect(opts.SuccessActionRedirect); err != nil {
+			return nil, err
+		}
+	}
+
+	postURL, fields, err := c.presignPostPolicy(ctx, policy)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PostForm{URL: postURL.String(), Fields: fields}, nil
+}
+
+// presignPostPolicy generates a presigned POST policy, retrying transient errors
+func (c *Client) presignPostPolicy(ctx context.Context, policy *minio.PostPolicy) (*url.URL, map[string]string, error) {
+	var formData map[string]string
+	presignedURL, err := withRetry(ctx, c, "PresignedPostPolicy", func() (*url.URL, error) {
+		var (
+			postURL *url.URL
+			err     error
+		)
+		postURL, formData, err = c.minio.PresignedPostPolicy(ctx, policy)
+		return postURL, err
+	})
+	return presignedURL, formData, err
 }
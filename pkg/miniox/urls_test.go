@@ -0,0 +1,199 @@
+package miniox
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+var roundTripPaths = []string{
+	"report.pdf",
+	"docs/2024/report.pdf",
+	"docs/a b+c.pdf",
+	"docs/100% #1?.txt",
+	"docs/ünïcode/файл.txt",
+}
+
+func TestParseObjectPathPublicURL(t *testing.T) {
+	tests := []struct {
+		name          string
+		baseDirPrefix string
+		publicURL     string
+	}{
+		{"no prefix", "", "https://cdn.example.com"},
+		{"with prefix", "app-data", "https://cdn.example.com/"},
+		{"public URL with path", "app-data/tenants", "https://example.com/static/files"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t, tt.baseDirPrefix)
+			c.publicBaseURL = tt.publicURL
+
+			for _, p := range roundTripPaths {
+				publicURL, err := c.GetPublicURL(p)
+				if err != nil {
+					t.Fatalf("GetPublicURL(%q): %v", p, err)
+				}
+				got, err := c.ParseObjectPath(publicURL.String())
+				if err != nil {
+					t.Fatalf("ParseObjectPath(%q): %v", publicURL, err)
+				}
+				if got != p {
+					t.Errorf("ParseObjectPath(%q) = %q, want %q", publicURL, got, p)
+				}
+			}
+		})
+	}
+}
+
+func TestParseObjectPathPresignedURL(t *testing.T) {
+	ctx := context.Background()
+
+	virtualHosted, err := minio.New("s3.example.com", &minio.Options{
+		Creds:        credentials.NewStaticV4("access", "secret", ""),
+		Region:       "us-east-1",
+		Secure:       true,
+		BucketLookup: minio.BucketLookupDNS,
+	})
+	if err != nil {
+		t.Fatalf("minio.New: %v", err)
+	}
+
+	tests := []struct {
+		name          string
+		baseDirPrefix string
+		minio         *minio.Client
+	}{
+		{"path-style without prefix", "", nil},
+		{"path-style with prefix", "app-data", nil},
+		{"virtual-hosted-style with prefix", "app-data", virtualHosted},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t, tt.baseDirPrefix)
+			if tt.minio != nil {
+				c.minio = tt.minio
+			}
+
+			for _, p := range roundTripPaths {
+				presigned, err := c.GetPresignedURL(ctx, p, time.Hour)
+				if err != nil {
+					t.Fatalf("GetPresignedURL(%q): %v", p, err)
+				}
+				got, err := c.ParseObjectPath(presigned.String())
+				if err != nil {
+					t.Fatalf("ParseObjectPath(%q): %v", presigned, err)
+				}
+				if got != p {
+					t.Errorf("ParseObjectPath(%q) = %q, want %q", presigned, got, p)
+				}
+			}
+		})
+	}
+}
+
+func TestParseObjectPathBarePath(t *testing.T) {
+	c := newTestClient(t, "app-data")
+
+	got, err := c.ParseObjectPath("test-bucket/app-data/docs/a%20b.pdf")
+	if err != nil {
+		t.Fatalf("ParseObjectPath: %v", err)
+	}
+	if got != "docs/a b.pdf" {
+		t.Errorf("ParseObjectPath = %q, want %q", got, "docs/a b.pdf")
+	}
+}
+
+func TestParseObjectPathForeign(t *testing.T) {
+	c := newTestClient(t, "app-data")
+	c.publicBaseURL = "https://cdn.example.com/files"
+
+	tests := []struct {
+		name       string
+		rawURL     string
+		wantBucket string
+	}{
+		{"foreign host", "https://evil.example.com/test-bucket/app-data/docs/report.pdf", ""},
+		{"public URL of another bucket", "https://cdn.example.com/files/other-bucket/app-data/report.pdf", "other-bucket"},
+		{"endpoint URL of another bucket", "http://localhost:9000/other-bucket/app-data/report.pdf", "other-bucket"},
+		{"outside base prefix", "http://localhost:9000/test-bucket/other-data/report.pdf", "test-bucket"},
+		{"bare path outside base prefix", "test-bucket/app-database/report.pdf", "test-bucket"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := c.ParseObjectPath(tt.rawURL)
+
+			var foreign *ErrForeignObjectURL
+			if !errors.As(err, &foreign) {
+				t.Fatalf("ParseObjectPath(%q) error = %v, want *ErrForeignObjectURL", tt.rawURL, err)
+			}
+			if foreign.Bucket != tt.wantBucket {
+				t.Errorf("Bucket = %q, want %q", foreign.Bucket, tt.wantBucket)
+			}
+		})
+	}
+}
+
+func TestComposeSources(t *testing.T) {
+	ctx := context.Background()
+	c := newTestClient(t, "app-data")
+
+	srcObjects := []minio.CopySrcOptions{
+		{Object: "parts/1"},
+		{Bucket: "test-bucket", Object: "parts/2"},
+		{Bucket: "other-bucket", Object: "shared/part3"},
+	}
+	original := slices.Clone(srcObjects)
+
+	got, err := c.composeSources(ctx, srcObjects)
+	if err != nil {
+		t.Fatalf("composeSources: %v", err)
+	}
+
+	want := []struct{ bucket, object string }{
+		{"test-bucket", "app-data/parts/1"},
+		{"test-bucket", "app-data/parts/2"},
+		{"other-bucket", "shared/part3"},
+	}
+	for i, w := range want {
+		if got[i].Bucket != w.bucket || got[i].Object != w.object {
+			t.Errorf("source %d = %s/%s, want %s/%s", i, got[i].Bucket, got[i].Object, w.bucket, w.object)
+		}
+	}
+
+	if !reflect.DeepEqual(srcObjects, original) {
+		t.Errorf("caller's sources were modified: %+v, want %+v", srcObjects, original)
+	}
+
+	// Reusing the caller's slice must not apply the prefix twice
+	again, err := c.composeSources(ctx, srcObjects)
+	if err != nil {
+		t.Fatalf("composeSources: %v", err)
+	}
+	if !reflect.DeepEqual(again, got) {
+		t.Errorf("second call = %+v, want %+v", again, got)
+	}
+}
+
+func TestComposeSourcesRejectsInvalidPath(t *testing.T) {
+	ctx := context.Background()
+	c := newTestClient(t, "")
+
+	if _, err := c.composeSources(ctx, []minio.CopySrcOptions{{Object: "../escape"}}); err == nil {
+		t.Error("composeSources accepted a path traversal")
+	}
+
+	// Sources in other buckets are not validated against the base directory
+	if _, err := c.composeSources(ctx, []minio.CopySrcOptions{{Bucket: "other-bucket", Object: "a/b"}}); err != nil {
+		t.Errorf("composeSources(other bucket) = %v, want nil", err)
+	}
+}
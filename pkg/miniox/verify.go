@@ -0,0 +1,186 @@
+package miniox
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/encrypt"
+)
+
+// defaultPartSize mirrors the part size minio-go uses to decide between single and multipart uploads
+const defaultPartSize = 16 * 1024 * 1024
+
+// ErrChecksumMismatch is returned when a stored object does not match the data that was uploaded
+type ErrChecksumMismatch struct {
+	ObjectPath string // Relative object path
+	Algorithm  string // "ETag" or the checksum algorithm name
+	Expected   string // Value computed client-side
+	Actual     string // Value reported by the server
+}
+
+// Error implements the error interface
+func (e *ErrChecksumMismatch) Error() string {
+	return fmt.Sprintf("%s mismatch for %s: expected %s, got %s", e.Algorithm, e.ObjectPath, e.Expected, e.Actual)
+}
+
+// PutObjectVerified uploads data and verifies that the stored object matches it
+// When opts.Checksum is set, the returned checksum is compared (full-object or composite for multipart uploads);
+// otherwise the ETag is compared against the expected MD5 or multipart ETag. Returns *ErrChecksumMismatch on mismatch
+func (c *Client) PutObjectVerified(ctx context.Context, objectPath string, data []byte, opts minio.PutObjectOptions) (minio.UploadInfo, error) {
+	size := int64(len(data))
+
+	// SSE-C and SSE-KMS ETags are not content hashes, so only checksums can be verified
+	sse := c.writeSSE(opts.ServerSideEncryption)
+	if !opts.Checksum.IsSet() && sse != nil && sse.Type() != encrypt.S3 {
+		return minio.UploadInfo{}, fmt.Errorf("ETag verification is not possible with %s encryption, set opts.Checksum instead", sse.Type())
+	}
+
+	parts, err := uploadParts(data, opts)
+	if err != nil {
+		return minio.UploadInfo{}, err
+	}
+
+	var algorithm, expected string
+	if opts.Checksum.IsSet() {
+		algorithm = opts.Checksum.String()
+		expected, err = expectedChecksum(data, parts, opts.Checksum)
+	} else {
+		algorithm = "ETag"
+		expected = expectedETag(parts)
+	}
+	if err != nil {
+		return minio.UploadInfo{}, err
+	}
+
+	uploadInfo, err := c.PutObject(ctx, objectPath, bytes.NewReader(data), size, opts)
+	if err != nil {
+		return uploadInfo, err
+	}
+
+	actual := uploadInfo.ETag
+	if opts.Checksum.IsSet() {
+		actual = uploadedChecksum(uploadInfo, opts.Checksum)
+	}
+
+	c.logDebug(ctx, "[MinIO] Verifying uploaded object",
+		slog.String("bucket", c.bucketName),
+		slog.String("object", uploadInfo.Key),
+		slog.String("algorithm", algorithm),
+		slog.Int("parts", len(parts)))
+
+	if !strings.EqualFold(trimPartsSuffix(actual), trimPartsSuffix(expected)) {
+		return uploadInfo, &ErrChecksumMismatch{
+			ObjectPath: uploadInfo.Key,
+			Algorithm:  algorithm,
+			Expected:   expected,
+			Actual:     actual,
+		}
+	}
+
+	return uploadInfo, nil
+}
+
+// uploadParts splits data the same way minio-go does for an upload of known size
+// A single part is returned when the upload is not multipart
+func uploadParts(data []byte, opts minio.PutObjectOptions) ([][]byte, error) {
+	size := int64(len(data))
+
+	threshold := int64(opts.PartSize)
+	if threshold == 0 {
+		threshold = defaultPartSize
+	}
+	if size <= threshold || opts.DisableMultipart {
+		return [][]byte{data}, nil
+	}
+
+	_, partSize, _, err := minio.OptimalPartInfo(size, opts.PartSize)
+	if err != nil {
+		return nil, err
+	}
+
+	var parts [][]byte
+	for start := int64(0); start < size; start += partSize {
+		parts = append(parts, data[start:min(start+partSize, size)])
+	}
+	return parts, nil
+}
+
+// expectedETag computes the ETag S3 reports for the given upload parts
+func expectedETag(parts [][]byte) string {
+	if len(parts) == 1 {
+		sum := md5.Sum(parts[0])
+		return hex.EncodeToString(sum[:])
+	}
+
+	hasher := md5.New()
+	for _, part := range parts {
+		sum := md5.Sum(part)
+		hasher.Write(sum[:])
+	}
+	return fmt.Sprintf("%s-%d", hex.EncodeToString(hasher.Sum(nil)), len(parts))
+}
+
+// expectedChecksum computes the checksum S3 reports for the given upload parts
+func expectedChecksum(data []byte, parts [][]byte, checksumType minio.ChecksumType) (string, error) {
+	if len(parts) == 1 || checksumType.FullObjectRequested() {
+		return checksumType.Base().ChecksumBytes(data).Encoded(), nil
+	}
+
+	objectParts := make([]minio.ObjectPart, len(parts))
+	for i, part := range parts {
+		encoded := checksumType.Base().ChecksumBytes(part).Encoded()
+		objectParts[i].PartNumber = i + 1
+		switch checksumType.Base() {
+		case minio.ChecksumCRC32:
+			objectParts[i].ChecksumCRC32 = encoded
+		case minio.ChecksumCRC32C:
+			objectParts[i].ChecksumCRC32C = encoded
+		case minio.ChecksumSHA1:
+			objectParts[i].ChecksumSHA1 = encoded
+		case minio.ChecksumSHA256:
+			objectParts[i].ChecksumSHA256 = encoded
+		case minio.ChecksumCRC64NVME:
+			objectParts[i].ChecksumCRC64NVME = encoded
+		}
+	}
+
+	composite, err := checksumType.CompositeChecksum(objectParts)
+	if err != nil {
+		return "", fmt.Errorf("failed to compute composite checksum: %w", err)
+	}
+	return fmt.Sprintf("%s-%d", composite.Encoded(), len(parts)), nil
+}
+
+// uploadedChecksum returns the checksum of the given type reported in the upload info
+func uploadedChecksum(uploadInfo minio.UploadInfo, checksumType minio.ChecksumType) string {
+	switch checksumType.Base() {
+	case minio.ChecksumCRC32:
+		return uploadInfo.ChecksumCRC32
+	case minio.ChecksumCRC32C:
+		return uploadInfo.ChecksumCRC32C
+	case minio.ChecksumSHA1:
+		return uploadInfo.ChecksumSHA1
+	case minio.ChecksumSHA256:
+		return uploadInfo.ChecksumSHA256
+	case minio.ChecksumCRC64NVME:
+		return uploadInfo.ChecksumCRC64NVME
+	default:
+		return ""
+	}
+}
+
+// trimPartsSuffix removes quotes and the "-<parts>" suffix of multipart ETags and composite checksums
+// Neither hex nor standard base64 contain '-', so the hash portion is never affected
+func trimPartsSuffix(value string) string {
+	value = strings.Trim(value, "\"")
+	if i := strings.IndexByte(value, '-'); i >= 0 {
+		return value[:i]
+	}
+	return value
+}
@@ -0,0 +1,96 @@
+package miniox
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/minio/minio-go/v7"
+)
+
+// ListObjectVersions lists all versions and delete markers with automatic bucket name and path prefix handling
+// Returned keys are relative; VersionID, IsLatest and IsDeleteMarker are preserved
+func (c *Client) ListObjectVersions(ctx context.Context, prefix string, recursive bool) <-chan minio.ObjectInfo {
+	return c.ListObjectsWithOpts(ctx, prefix, ListObjectsOpts{
+		Recursive:    recursive,
+		WithVersions: true,
+	})
+}
+
+// StatObjectVersion gets information about a specific version of an object
+func (c *Client) StatObjectVersion(ctx context.Context, objectPath string, versionID string) (minio.ObjectInfo, error) {
+	return c.StatObject(ctx, objectPath, minio.StatObjectOptions{VersionID: versionID})
+}
+
+// GetObjectVersion gets a specific version of an object
+func (c *Client) GetObjectVersion(ctx context.Context, objectPath string, versionID string) (*minio.Object, error) {
+	return c.GetObject(ctx, objectPath, minio.GetObjectOptions{VersionID: versionID})
+}
+
+// RestoreObjectVersion promotes an older version of an object to the latest version by copying it onto itself
+func (c *Client) RestoreObjectVersion(ctx context.Context, objectPath string, versionID string) (minio.UploadInfo, error) {
+	return c.RestoreObjectVersionTo(ctx, objectPath, versionID, objectPath)
+}
+
+// RestoreObjectVersionTo copies a specific version of an object to a destination path as a new latest version
+// Fails if the version is a delete marker, since there is no content to restore
+func (c *Client) RestoreObjectVersionTo(ctx context.Context, objectPath string, versionID string, destObjectPath string) (uploadInfo minio.UploadInfo, err error) {
+	ctx, span := c.startOperation(ctx, "RestoreObjectVersion",
+		slog.String("object", objectPath),
+		slog.String("versionID", versionID),
+		slog.String("dest", destObjectPath))
+	defer func() { span.End(err) }()
+
+	if err := c.validatePath(ctx, objectPath); err != nil {
+		return minio.UploadInfo{}, err
+	}
+	if err := c.validatePath(ctx, destObjectPath); err != nil {
+		return minio.UploadInfo{}, err
+	}
+	if versionID == "" {
+		return minio.UploadInfo{}, fmt.Errorf("version ID is required")
+	}
+
+	fullSrcPath := c.buildPath(ctx, objectPath)
+	fullDestPath := c.buildPath(ctx, destObjectPath)
+
+	c.logDebug(ctx, "[MinIO] Restoring object version",
+		slog.String("bucket", c.bucketName),
+		slog.String("src", fullSrcPath),
+		slog.String("versionID", versionID),
+		slog.String("dest", fullDestPath))
+
+	info, err := withRetry(ctx, c, "StatObject", func() (minio.ObjectInfo, error) {
+		return c.minio.StatObject(ctx, c.bucketName, fullSrcPath, minio.StatObjectOptions{
+			VersionID:            versionID,
+			ServerSideEncryption: c.readSSE(nil),
+		})
+	})
+	if info.IsDeleteMarker {
+		return minio.UploadInfo{}, fmt.Errorf("version %s of %s is a delete marker and cannot be restored", versionID, objectPath)
+	}
+	if err != nil {
+		return minio.UploadInfo{}, err
+	}
+
+	defer c.invalidateFullPath(fullDestPath)
+	uploadInfo, err = withRetry(ctx, c, "CopyObject", func() (minio.UploadInfo, error) {
+		return c.minio.CopyObject(ctx, minio.CopyDestOptions{
+			Bucket:     c.bucketName,
+			Object:     fullDestPath,
+			Encryption: c.writeSSE(nil),
+		}, minio.CopySrcOptions{
+			Bucket:     c.bucketName,
+			Object:     fullSrcPath,
+			VersionID:  versionID,
+			Encryption: c.readSSE(nil),
+		})
+	})
+	if err != nil {
+		return uploadInfo, err
+	}
+
+	// Strip base path from returned upload info
+	uploadInfo.Key = c.stripBasePath(ctx, uploadInfo.Key)
+	return uploadInfo, nil
+}
@@ -0,0 +1,113 @@
+package miniox
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/minio/minio-go/v7"
+)
+
+func TestObjectVersions(t *testing.T) {
+	ctx := context.Background()
+	c, fake := newFakeS3Client(t, "app-data")
+	fake.versioned = true
+
+	put := func(objectPath string, content string) string {
+		t.Helper()
+		info, err := c.PutObject(ctx, objectPath, bytes.NewReader([]byte(content)), int64(len(content)), minio.PutObjectOptions{})
+		if err != nil {
+			t.Fatalf("PutObject(%s): %v", objectPath, err)
+		}
+		if info.VersionID == "" {
+			t.Fatalf("PutObject(%s) returned no version ID", objectPath)
+		}
+		return info.VersionID
+	}
+
+	v1 := put("docs/report.txt", "first")
+	v2 := put("docs/report.txt", "second version")
+	put("other/note.txt", "outside the listed prefix")
+	if err := c.RemoveObject(ctx, "docs/report.txt", minio.RemoveObjectOptions{}); err != nil {
+		t.Fatalf("RemoveObject: %v", err)
+	}
+
+	var versions []minio.ObjectInfo
+	for info := range c.ListObjectVersions(ctx, "docs/", true) {
+		if info.Err != nil {
+			t.Fatalf("ListObjectVersions: %v", info.Err)
+		}
+		versions = append(versions, info)
+	}
+
+	if len(versions) != 3 {
+		t.Fatalf("ListObjectVersions returned %d versions, want 3: %+v", len(versions), versions)
+	}
+	for _, info := range versions {
+		if info.Key != "docs/report.txt" {
+			t.Errorf("version key = %q, want docs/report.txt", info.Key)
+		}
+		if info.VersionID == "" {
+			t.Errorf("version of %q has no version ID", info.Key)
+		}
+	}
+
+	// Newest first: the delete marker, then the two writes
+	if marker := versions[0]; !marker.IsDeleteMarker || !marker.IsLatest {
+		t.Errorf("latest version = %+v, want the latest delete marker", marker)
+	}
+	if got := versions[1]; got.VersionID != v2 || got.IsLatest || got.IsDeleteMarker || got.Size != int64(len("second version")) {
+		t.Errorf("second version = %+v, want %s", got, v2)
+	}
+	if got := versions[2]; got.VersionID != v1 || got.IsLatest || got.IsDeleteMarker || got.Size != int64(len("first")) {
+		t.Errorf("first version = %+v, want %s", got, v1)
+	}
+
+	info, err := c.StatObjectVersion(ctx, "docs/report.txt", v1)
+	if err != nil {
+		t.Fatalf("StatObjectVersion: %v", err)
+	}
+	if info.Key != "docs/report.txt" || info.VersionID != v1 || info.Size != int64(len("first")) {
+		t.Errorf("StatObjectVersion = %+v, want %s of size %d", info, v1, len("first"))
+	}
+
+	object, err := c.GetObjectVersion(ctx, "docs/report.txt", v2)
+	if err != nil {
+		t.Fatalf("GetObjectVersion: %v", err)
+	}
+	defer object.Close()
+	if content, err := io.ReadAll(object); err != nil || string(content) != "second version" {
+		t.Errorf("GetObjectVersion content = %q, %v, want %q", content, err, "second version")
+	}
+
+	// The latest version is the delete marker, so the object itself is gone
+	if _, err := c.StatObject(ctx, "docs/report.txt", minio.StatObjectOptions{}); err == nil {
+		t.Error("StatObject found an object whose latest version is a delete marker")
+	}
+}
+
+func TestRestoreObjectVersion(t *testing.T) {
+	ctx := context.Background()
+	c, fake := newFakeS3Client(t, "app-data")
+	fake.versioned = true
+
+	first, err := c.PutObject(ctx, "config.json", bytes.NewReader([]byte(`{"v":1}`)), 7, minio.PutObjectOptions{})
+	if err != nil {
+		t.Fatalf("PutObject: %v", err)
+	}
+	if _, err := c.PutObject(ctx, "config.json", bytes.NewReader([]byte(`{"v":2}`)), 7, minio.PutObjectOptions{}); err != nil {
+		t.Fatalf("PutObject: %v", err)
+	}
+
+	if _, err := c.RestoreObjectVersion(ctx, "config.json", first.VersionID); err != nil {
+		t.Fatalf("RestoreObjectVersion: %v", err)
+	}
+	if latest := fake.object("app-data/config.json"); latest == nil || string(latest.data) != `{"v":1}` {
+		t.Errorf("latest version after restore = %v, want the first version's content", latest)
+	}
+
+	if _, err := c.RestoreObjectVersion(ctx, "config.json", ""); err == nil {
+		t.Error("RestoreObjectVersion accepted an empty version ID")
+	}
+}
@@ -0,0 +1,142 @@
+package miniox
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"log/slog"
+	"path"
+	"strings"
+
+	"github.com/minio/minio-go/v7"
+)
+
+// SkipDir can be returned by a WalkObjects callback to skip the rest of a folder
+// It is the same value as fs.SkipDir
+var SkipDir = fs.SkipDir
+
+// SkipAll can be returned by a WalkObjects callback to stop the walk without an error
+// It is the same value as fs.SkipAll
+var SkipAll = fs.SkipAll
+
+// WalkObjects calls fn for every object under a prefix with keys relative to the base directory prefix
+// With recursive set, all keys come from a single flat listing. Otherwise the walk lists one folder level at a
+// time like fs.WalkDir: folders are reported as entries whose keys end in "/" before their contents, and
+// returning SkipDir for a folder skips it entirely. Returning SkipDir for an object skips the remaining entries
+// of its folder, SkipAll stops the walk and returns nil, and any other error stops the walk and is returned.
+// Listings are cancelled as soon as the walk stops
+func (c *Client) WalkObjects(ctx context.Context, prefix string, recursive bool, fn func(info minio.ObjectInfo) error) (err error) {
+	ctx, span := c.startSpan(ctx, "WalkObjects",
+		slog.String("prefix", prefix),
+		slog.Bool("recursive", recursive))
+	defer func() { span.End(err) }()
+
+	if err := c.validatePath(ctx, prefix); err != nil {
+		return err
+	}
+
+	fullPrefix := c.buildPrefix(ctx, prefix)
+
+	c.logDebug(ctx, "[MinIO] Walking objects",
+		slog.String("bucket", c.bucketName),
+		slog.String("prefix", fullPrefix),
+		slog.Bool("recursive", recursive))
+
+	if recursive {
+		err = c.walkFlat(ctx, fullPrefix, fn)
+	} else {
+		err = c.walkLevels(ctx, fullPrefix, fn)
+	}
+	if errors.Is(err, SkipAll) || errors.Is(err, SkipDir) {
+		return nil
+	}
+	return err
+}
+
+// walkFlat walks a single recursive listing
+func (c *Client) walkFlat(ctx context.Context, fullPrefix string, fn func(minio.ObjectInfo) error) error {
+	listCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	opts := minio.ListObjectsOptions{
+		Prefix:    fullPrefix,
+		Recursive: true,
+	}
+
+	// Folder whose remaining objects are skipped after SkipDir
+	skipped := ""
+	for objectInfo := range c.minio.ListObjects(listCtx, c.bucketName, opts) {
+		if objectInfo.Err != nil {
+			return objectInfo.Err
+		}
+
+		key := c.stripBasePath(ctx, objectInfo.Key)
+		if skipped != "" && strings.HasPrefix(key, skipped) {
+			continue
+		}
+
+		objectInfo.Key = key
+		if err := fn(objectInfo); err != nil {
+			if !errors.Is(err, SkipDir) {
+				return err
+			}
+			skipped = path.Dir(key) + "/"
+			if skipped == "./" {
+				// An object at the top of the walk skips everything
+				return SkipAll
+			}
+		}
+	}
+	return nil
+}
+
+// walkLevels walks one folder level at a time, depth first
+// Each level is read completely before its entries are visited, so no listing is left open while descending
+func (c *Client) walkLevels(ctx context.Context, fullPrefix string, fn func(minio.ObjectInfo) error) error {
+	entries, err := c.listLevel(ctx, fullPrefix)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		fullKey := entry.Key
+		entry.Key = c.stripBasePath(ctx, fullKey)
+
+		err := fn(entry)
+		isFolder := strings.HasSuffix(fullKey, "/")
+		switch {
+		case errors.Is(err, SkipDir) && isFolder:
+			continue
+		case err != nil:
+			// SkipDir on an object skips the rest of this level
+			return err
+		}
+
+		if isFolder && fullKey != fullPrefix {
+			if err := c.walkLevels(ctx, fullKey, fn); err != nil && !errors.Is(err, SkipDir) {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// listLevel lists the direct entries under a prefix; folders are returned as keys ending in "/"
+func (c *Client) listLevel(ctx context.Context, fullPrefix string) ([]minio.ObjectInfo, error) {
+	listCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	opts := minio.ListObjectsOptions{
+		Prefix:    fullPrefix,
+		Recursive: false,
+	}
+
+	var entries []minio.ObjectInfo
+	for objectInfo := range c.minio.ListObjects(listCtx, c.bucketName, opts) {
+		if objectInfo.Err != nil {
+			return nil, objectInfo.Err
+		}
+		entries = append(entries, objectInfo)
+	}
+	return entries, nil
+}
@@ -6,6 +6,14 @@ type Config = miniox.Config
 
 type Client = miniox.Client
 
+type Tracer = miniox.Tracer
+
+type Span = miniox.Span
+
 func New(config *Config) (*Client, error) {
 	return miniox.New(config)
 }
+
+func NewFromEnv(prefix string) (*Client, error) {
+	return miniox.NewFromEnv(prefix)
+}